@@ -0,0 +1,82 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+type applyOptions struct {
+	createOptions
+	dir            string
+	outsideSources bool
+}
+
+func applyCommand(cli command.Cli) *cobra.Command {
+	opts := applyOptions{}
+	cmd := &cobra.Command{
+		Use:   "apply [OPTIONS] MANIFEST",
+		Short: "Create a structure from a manifest",
+		Long:  "Create the directories, files and symlinks declared in a YAML or JSON manifest.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runApply(opts, cli, args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.dir, "dir", "C", "", "Directory to apply the manifest in (default: the manifest root, or the current directory)")
+	flags.BoolVar(&opts.outsideSources, "allow-outside-sources", false, "Allow content sources outside the manifest directory")
+	opts.createOptions.addFlags(flags)
+
+	return cmd
+}
+
+func runApply(opts applyOptions, cli command.Cli, file string) error {
+	m, err := manifest.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	root := opts.dir
+	if root == "" {
+		root = m.Root
+	}
+
+	entries, err := m.Resolve(root, filepath.Dir(file), opts.outsideSources)
+	if err != nil {
+		return err
+	}
+
+	return runCreate(cli, opts.createOptions, entries)
+}
@@ -27,10 +27,14 @@
 package burrow
 
 import (
+	"os"
+
 	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/config"
 	create "github.com/elaurentium/burrow/internal/fs"
 
 	"github.com/elaurentium/burrow/internal/helper"
+	"github.com/elaurentium/burrow/internal/shell"
 	"github.com/spf13/cobra"
 )
 
@@ -41,27 +45,86 @@ type ProjectOptions struct {
 func RootCmd(cli command.Cli) *cobra.Command {
 	opts := &ProjectOptions{}
 	var (
-		version bool
+		version    bool
+		treeFile   string
+		createOpts createOptions
+		seedOpts   contentOptions
+		files      []string
+		dirs       []string
+		hardlinks  bool
+		autoCd     bool
 	)
 	c := &cobra.Command{
 		Use:   helper.Usage,
 		Short: "Directory/File Creation CLI Tool",
-		Long:  "Create directories and files quickly. Paths with extensions are treated as files; others as directories.",
+		Long:  "Create directories and files quickly. Paths with extensions are treated as files and others as directories; a trailing / or --dir forces a directory and --file a file, see b classify. Brace expressions such as src/{api,web}/main.go and day{01..31}.md are expanded.",
 		Args:  cobra.ArbitraryArgs,
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Arguments are valid from here on, failures are not usage errors
+			cmd.SilenceUsage = true
 			if version {
 				versionCommand(cli)
 				return nil
 			}
-			// Constructor of creator
-			creator := create.NewCreator()
-			creator.Create(args)
-			return nil
+			if treeFile != "" {
+				treePaths, err := readTree(cli, treeFile)
+				if err != nil {
+					return err
+				}
+				args = append(args, treePaths...)
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			// The config only enables auto-cd in shells with the hook installed
+			createOpts.cd = autoCd || (config.Bool(cfg.AutoCd, false) && os.Getenv(shell.SessionEnv) != "")
+			createOpts.boost = true
+			entries, err := classifier(cfg, hardlinks).EntriesFor(args)
+			if err != nil {
+				return err
+			}
+			forced, err := create.EntriesOf(files, create.TypeFile)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, forced...)
+			if forced, err = create.EntriesOf(dirs, create.TypeDir); err != nil {
+				return err
+			}
+			entries = append(entries, forced...)
+			if err := seedOpts.seed(cfg, entries); err != nil {
+				return err
+			}
+			return runCreate(cli, createOpts, entries)
 		},
 	}
 
+	flags := c.Flags()
+	flags.StringVarP(&treeFile, "tree", "t", "", "Create the paths described by an indented tree file (\"-\" reads stdin)")
+	flags.StringArrayVar(&files, "file", nil, "Create this path as a file whatever its name (repeatable)")
+	flags.StringArrayVar(&dirs, "dir", nil, "Create this path as a directory whatever its name (repeatable)")
+	flags.BoolVarP(&autoCd, "cd", "c", false, "Change the shell into the deepest created directory (needs the b init hook)")
+	flags.BoolVar(&hardlinks, "hardlink", false, "Make link=>target arguments hard links instead of symlinks")
+	createOpts.addFlags(flags)
+	seedOpts.addFlags(flags)
+
 	c.AddCommand(
 		updateCommand(),
+		applyCommand(cli),
+		exportCommand(cli),
+		checkCommand(cli),
+		mirrorCommand(cli),
+		gitkeepCommand(cli),
+		initCommand(cli),
+		hookCommand(cli),
+		jumpCommand(cli),
+		newCommand(cli),
+		licenseCommand(cli),
+		classifyCommand(cli),
+		templateCommand(cli),
+		undoCommand(cli),
+		historyCommand(cli),
 		statCommand(opts),
 		versionCommand(cli),
 	)
@@ -0,0 +1,137 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/manifest"
+	"github.com/elaurentium/burrow/pkg/formatter"
+	"github.com/spf13/cobra"
+)
+
+type checkOptions struct {
+	dir      string
+	format   string
+	noIgnore bool
+}
+
+func checkCommand(cli command.Cli) *cobra.Command {
+	opts := checkOptions{}
+	cmd := &cobra.Command{
+		Use:          "check [OPTIONS] MANIFEST",
+		Short:        "Check the working tree against a manifest",
+		Long:         "Report missing, extra and mismatched paths between a manifest and the working tree. Exits non-zero when they differ.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCheck(opts, cli, args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.dir, "dir", "C", "", "Directory to check (default: the manifest root, or the current directory)")
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output. Values: [pretty | json]. (Default: pretty)")
+	flags.BoolVar(&opts.noIgnore, "no-gitignore", false, "Also report extra paths ignored by .gitignore files")
+
+	return cmd
+}
+
+func runCheck(opts checkOptions, cli command.Cli, file string) error {
+	m, err := manifest.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	root := opts.dir
+	if root == "" {
+		root = m.Root
+	}
+	if root == "" {
+		root = "."
+	}
+
+	checkOpts := manifest.CheckOptions{GitIgnore: !opts.noIgnore}
+	// A manifest kept in the tree it describes is not an extra path
+	if rel, ok := below(root, file); ok {
+		checkOpts.Skip = append(checkOpts.Skip, rel)
+	}
+	report, err := manifest.Check(m, root, checkOpts)
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", root, err)
+	}
+
+	if opts.format == formatter.JSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(cli.Out(), string(data))
+	} else {
+		printReport(cli, report)
+	}
+
+	if !report.OK {
+		return fmt.Errorf("%s does not match %s: %d issue(s)", root, file, len(report.Issues))
+	}
+	return nil
+}
+
+func printReport(cli command.Cli, report *manifest.Report) {
+	for _, issue := range report.Issues {
+		switch issue.Kind {
+		case manifest.Missing, manifest.Extra:
+			_, _ = fmt.Fprintf(cli.Out(), "%-8s %s\n", issue.Kind, issue.Path)
+		default:
+			_, _ = fmt.Fprintf(cli.Out(), "%-8s %s (expected %s, found %s)\n", issue.Kind, issue.Path, issue.Expected, issue.Actual)
+		}
+	}
+	if report.OK {
+		_, _ = fmt.Fprintf(cli.Out(), "%s matches the manifest\n", report.Root)
+	}
+}
+
+// below returns path relative to dir when it lies inside it
+func below(dir, path string) (string, bool) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
@@ -0,0 +1,101 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/config"
+	create "github.com/elaurentium/burrow/internal/fs"
+	pt "github.com/elaurentium/burrow/internal/paths"
+	"github.com/elaurentium/burrow/pkg/formatter"
+	"github.com/spf13/cobra"
+)
+
+type classifyOptions struct {
+	format string
+}
+
+func classifyCommand(cli command.Cli) *cobra.Command {
+	opts := classifyOptions{}
+	cmd := &cobra.Command{
+		Use:   "classify [OPTIONS] PATH...",
+		Short: "Explain whether paths would be created as files or directories",
+		Long:  "Show whether each path would be created as a file or a directory, and the rule that decided it. Names and patterns from the user and project config are applied first.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runClassify(cli, opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.format, "format", "", "Format the output. Values: [pretty | json]. (Default: pretty)")
+
+	return cmd
+}
+
+// classifier returns the classifier with the names and patterns from the
+// config
+func classifier(cfg *config.Config, hardlinks bool) *create.Classifier {
+	return &create.Classifier{Files: cfg.Classify.Files, Dirs: cfg.Classify.Dirs, Hardlinks: hardlinks}
+}
+
+func runClassify(cli command.Cli, opts classifyOptions, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	c := classifier(cfg, false)
+
+	paths, err := pt.ExpandAll(args)
+	if err != nil {
+		return err
+	}
+	results := make([]create.Classification, 0, len(paths))
+	for _, path := range paths {
+		results = append(results, c.Classify(path))
+	}
+
+	if opts.format == formatter.JSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(cli.Out(), string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cli.Out(), 0, 4, 2, ' ', 0)
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Path, r.Type, r.Rule)
+	}
+	return w.Flush()
+}
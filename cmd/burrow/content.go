@@ -0,0 +1,104 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elaurentium/burrow/internal/config"
+	"github.com/elaurentium/burrow/internal/content"
+	create "github.com/elaurentium/burrow/internal/fs"
+	"github.com/elaurentium/burrow/internal/license"
+	"github.com/spf13/pflag"
+)
+
+// contentOptions control the starter content and license header of new
+// files
+type contentOptions struct {
+	empty   bool
+	license string
+	holder  string
+	year    int
+}
+
+func (o *contentOptions) addFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.empty, "empty", false, "Create files empty instead of seeding them with starter content")
+	flags.StringVar(&o.license, "license", "", "Prepend an SPDX license header to new source files, e.g. MIT or Apache-2.0")
+	flags.StringVar(&o.holder, "holder", "", "Copyright holder of the license header (default: git user.name)")
+	flags.IntVar(&o.year, "year", 0, "Copyright year of the license header (default: the current year)")
+}
+
+// seed fills new files with the content rules from the config and the
+// builtin ones, unless files are to be created empty, and prepends the
+// license header to source files
+func (o contentOptions) seed(cfg *config.Config, entries []create.Entry) error {
+	if !o.empty && !config.Bool(cfg.Content.Empty, false) {
+		if err := content.Seed(entries, content.Rules(cfg)); err != nil {
+			return err
+		}
+	}
+
+	// The holder is only needed when some file gets a header
+	notice, err := o.notice(cfg, license.Applies(entries))
+	if err != nil || notice.ID == "" {
+		return err
+	}
+	license.Apply(entries, notice)
+	return nil
+}
+
+// notice resolves the license header from the flags, the config and git.
+// The holder is only looked up when a header will be written.
+func (o contentOptions) notice(cfg *config.Config, headers bool) (license.Notice, error) {
+	n := license.Notice{ID: o.license, Holder: o.holder, Year: o.year}
+	if n.ID == "" {
+		n.ID = cfg.License.ID
+	}
+	if n.ID == "" {
+		return n, nil
+	}
+	if !license.ValidID(n.ID) {
+		return n, fmt.Errorf("invalid SPDX license identifier %q", n.ID)
+	}
+	if !headers {
+		return license.Notice{}, nil
+	}
+	if n.Holder == "" {
+		n.Holder = cfg.License.Holder
+	}
+	if n.Holder == "" {
+		n.Holder = license.GitHolder()
+	}
+	if n.Holder == "" {
+		return n, license.ErrNoHolder
+	}
+	if n.Year == 0 {
+		n.Year = time.Now().Year()
+	}
+	return n, nil
+}
@@ -0,0 +1,307 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/config"
+	create "github.com/elaurentium/burrow/internal/fs"
+	"github.com/elaurentium/burrow/internal/journal"
+	"github.com/elaurentium/burrow/internal/jump"
+	"github.com/elaurentium/burrow/internal/manifest"
+	"github.com/elaurentium/burrow/internal/shell"
+	"github.com/elaurentium/burrow/internal/tree"
+	"github.com/elaurentium/burrow/pkg/formatter"
+	"github.com/spf13/pflag"
+)
+
+// createOptions are the flags shared by every command that creates paths
+type createOptions struct {
+	dryRun  bool
+	format  string
+	quiet   bool
+	atomic  bool
+	strict  bool
+	gitkeep bool
+	cd      bool // change the shell into the created path, set by the root command
+	boost   bool // give the requested directory a head start in jump, set by the root command
+	workers int
+	mode    string
+	dirMode string
+	owner   string
+}
+
+func (o *createOptions) addFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.dryRun, "dry-run", false, "Print what would be created without touching the disk")
+	flags.StringVar(&o.format, "format", "", "Format the dry-run plan. Values: [pretty | json]. (Default: pretty)")
+	flags.BoolVarP(&o.quiet, "quiet", "q", false, "Do not print the tree of created paths")
+	flags.BoolVar(&o.atomic, "atomic", false, "Create all paths or none: roll back everything on the first failure")
+	flags.BoolVar(&o.strict, "strict", false, "Fail symlinks whose target does not exist instead of warning")
+	flags.BoolVar(&o.gitkeep, "gitkeep", false, "Add a .gitkeep placeholder to created directories that are left empty")
+	flags.StringVar(&o.mode, "mode", "", "Permissions of created files, e.g. 0640 (default: 0644 minus the umask)")
+	flags.StringVar(&o.dirMode, "dir-mode", "", "Permissions of created directories, e.g. 0750 (default: 0755 minus the umask)")
+	flags.StringVar(&o.owner, "owner", "", "Owner of created paths as user:group (requires privileges)")
+	flags.IntVarP(&o.workers, "workers", "j", 0, "Create paths with this many parallel workers (0 or 1: sequential)")
+}
+
+// newCreator returns a creator configured from the flags
+func (o *createOptions) newCreator() (*create.Creator, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	creator := create.NewCreator()
+	creator.Atomic = o.atomic
+	creator.Strict = o.strict
+	if o.gitkeep || config.Bool(cfg.Gitkeep.Enabled, false) {
+		creator.Gitkeep = cfg.Gitkeep.Placeholder()
+	}
+	creator.Workers = o.workers
+
+	if o.mode != "" {
+		mode, err := manifest.ParseMode(o.mode)
+		if err != nil {
+			return nil, err
+		}
+		creator.FileMode, creator.FileChmod = mode.FileMode(), true
+	}
+	if o.dirMode != "" {
+		mode, err := manifest.ParseMode(o.dirMode)
+		if err != nil {
+			return nil, err
+		}
+		creator.DirMode, creator.DirChmod = mode.FileMode(), true
+	}
+	if o.owner != "" {
+		uid, gid, err := create.ParseOwner(o.owner)
+		if err != nil {
+			return nil, err
+		}
+		creator.Uid, creator.Gid = uid, gid
+	}
+	return creator, nil
+}
+
+// runCreate creates the entries and reports the result as a tree, or
+// prints the plan for them on --dry-run
+func runCreate(cli command.Cli, opts createOptions, entries []create.Entry) error {
+	creator, err := opts.newCreator()
+	if err != nil {
+		return err
+	}
+	if opts.dryRun {
+		return printPlan(cli, opts, creator.Plan(entries))
+	}
+
+	results, err := creator.Run(entries)
+	recordRun(cli, results)
+	if opts.boost {
+		boostCreated(cli, entries, results)
+	}
+	if err == nil && opts.cd {
+		changeDir(cli, results)
+	}
+	if opts.quiet {
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				_, _ = fmt.Fprintln(cli.Err(), result.Err)
+			} else if result.Warning != "" {
+				_, _ = fmt.Fprintf(cli.Err(), "%s: %s\n", result.Path, result.Warning)
+			}
+		}
+		return nil
+	}
+	if printErr := printResults(cli, results); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+func printResults(cli command.Cli, results []create.Result) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	items := make([]tree.Item, 0, len(results))
+	seen := make(map[string]int)
+	for _, r := range results {
+		// A path touched twice keeps its first outcome unless it then failed
+		i, ok := seen[r.Path]
+		if ok && r.Err == nil {
+			continue
+		}
+
+		item := tree.Item{Path: r.Path, Dir: r.Type == create.TypeDir, Color: formatter.Green, Note: linkNote(r.Type, r.Target)}
+		switch {
+		case r.Status == create.StatusExisted:
+			item.Note, item.Color = "(exists)", formatter.Faint
+			if r.Type != create.TypeDir {
+				item.Color = formatter.Yellow
+			}
+		case r.Status == create.StatusRolledBack:
+			item.Note, item.Color = "(rolled back)", formatter.Faint
+		case r.Err != nil:
+			item.Note, item.Color = fmt.Sprintf("(failed: %v)", r.Err), formatter.Red
+		case r.Warning != "":
+			item.Note, item.Color = fmt.Sprintf("%s (warning: %s)", item.Note, r.Warning), formatter.Yellow
+		}
+		if ok {
+			items[i] = item
+			continue
+		}
+		seen[r.Path] = len(items)
+		items = append(items, item)
+	}
+	return tree.Render(cli.Out(), items, cli.Out().IsTerminal())
+}
+
+func printPlan(cli command.Cli, opts createOptions, plan []create.PlannedEntry) error {
+	if opts.format == formatter.JSON {
+		if plan == nil {
+			plan = []create.PlannedEntry{}
+		}
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(cli.Out(), string(data))
+		return nil
+	}
+
+	items := make([]tree.Item, 0, len(plan))
+	for _, p := range plan {
+		item := tree.Item{Path: p.Path, Dir: p.Type == create.TypeDir, Note: linkNote(p.Type, p.Target)}
+		switch {
+		case p.Action == create.ActionExists:
+			item.Note = "(exists)"
+		case p.Action == create.ActionFail:
+			item.Note = fmt.Sprintf("(would fail: %s)", p.Reason)
+		case p.Reason != "":
+			item.Note = fmt.Sprintf("%s (warning: %s)", item.Note, p.Reason)
+		}
+		items = append(items, item)
+	}
+	return tree.Render(cli.Out(), items, false)
+}
+
+// boostCreated gives the deepest directory the run was asked for, and
+// created, a head start in the jump database, as it is likely to be visited
+// next. Implicit parents are left alone so large runs do not flood it.
+func boostCreated(cli command.Cli, entries []create.Entry, results []create.Result) {
+	requested := make(map[string]bool)
+	for _, e := range entries {
+		if e.Type == create.TypeDir {
+			requested[filepath.Clean(e.Path)] = true
+		}
+	}
+
+	dir, depth := "", -1
+	for _, r := range results {
+		if r.Type != create.TypeDir || r.Status != create.StatusCreated || !requested[r.Path] {
+			continue
+		}
+		if d := strings.Count(r.Path, string(filepath.Separator)); d > depth {
+			dir, depth = r.Path, d
+		}
+	}
+	if dir == "" {
+		return
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return
+	}
+	db, err := jump.Open()
+	if err == nil {
+		db.Boost(abs)
+		err = db.Save()
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(cli.Err(), "failed to record created directories for jump: %v\n", err)
+	}
+}
+
+// changeDir asks the shell hook to change into the deepest created or
+// existing directory, or the parent of the deepest file
+func changeDir(cli command.Cli, results []create.Result) {
+	dir, depth := "", -1
+	for _, r := range results {
+		if r.Status != create.StatusCreated && r.Status != create.StatusExisted {
+			continue
+		}
+		path := r.Path
+		if r.Type != create.TypeDir {
+			path = filepath.Dir(path)
+		}
+		if d := strings.Count(filepath.Clean(path), string(filepath.Separator)); d > depth {
+			dir, depth = path, d
+		}
+	}
+	if dir == "" {
+		return
+	}
+	if err := shell.SetPendingCd(dir); err != nil {
+		_, _ = fmt.Fprintf(cli.Err(), "cannot change into %s: %v\n", dir, err)
+	}
+}
+
+// linkNote shows the target of a link next to it in a report
+func linkNote(t create.EntryType, target string) string {
+	switch t {
+	case create.TypeSymlink:
+		return "-> " + target
+	case create.TypeHardlink:
+		return "=> " + target
+	}
+	return ""
+}
+
+// recordRun adds the run to the journal so it can be undone. A journal that
+// cannot be written never fails the run itself.
+func recordRun(cli command.Cli, results []create.Result) {
+	j, err := journal.Open()
+	if err == nil {
+		defer j.Close()
+		var run *journal.Run
+		if run, err = j.Add(os.Args[1:], results); err == nil && run != nil {
+			err = j.Save()
+		}
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(cli.Err(), "failed to record run in the journal: %v\n", err)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	format   string
+	output   string
+	content  bool
+	maxSize  int64
+	modes    bool
+	symlinks bool
+	include  []string
+	exclude  []string
+	noIgnore bool
+}
+
+func exportCommand(cli command.Cli) *cobra.Command {
+	opts := exportOptions{}
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS] [DIR]",
+		Short: "Export a directory as a manifest",
+		Long:  "Walk a directory and print a manifest of its structure that can be replayed with apply.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runExport(opts, cli, dir)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output. Values: [yaml | json]. (Default: yaml, or implied by --output)")
+	flags.StringVarP(&opts.output, "output", "o", "", "Write the manifest to a file instead of stdout")
+	flags.BoolVar(&opts.content, "content", false, "Include the content of text files")
+	flags.Int64Var(&opts.maxSize, "max-size", 64*1024, "Largest file, in bytes, whose content is included")
+	flags.BoolVar(&opts.modes, "modes", false, "Record permission modes")
+	flags.BoolVar(&opts.symlinks, "symlinks", true, "Record symlinks")
+	flags.StringArrayVar(&opts.include, "include", nil, "Only export paths matching this glob (repeatable)")
+	flags.StringArrayVar(&opts.exclude, "exclude", nil, "Skip paths matching this glob (repeatable)")
+	flags.BoolVar(&opts.noIgnore, "no-gitignore", false, "Do not skip paths ignored by .gitignore files")
+
+	return cmd
+}
+
+func runExport(opts exportOptions, cli command.Cli, dir string) error {
+	m, err := manifest.Export(dir, manifest.ExportOptions{
+		Content:   opts.content,
+		MaxSize:   opts.maxSize,
+		Modes:     opts.modes,
+		Symlinks:  opts.symlinks,
+		Include:   opts.include,
+		Exclude:   opts.exclude,
+		GitIgnore: !opts.noIgnore,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", dir, err)
+	}
+
+	format := opts.format
+	if format == "" {
+		format = manifest.FormatOf(opts.output)
+	}
+	data, err := m.Marshal(format)
+	if err != nil {
+		return err
+	}
+
+	if opts.output != "" {
+		return os.WriteFile(opts.output, data, 0644)
+	}
+	_, err = cli.Out().Write(data)
+	return err
+}
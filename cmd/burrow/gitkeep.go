@@ -0,0 +1,97 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/config"
+	create "github.com/elaurentium/burrow/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+type gitkeepOptions struct {
+	name   string
+	dryRun bool
+}
+
+func gitkeepCommand(cli command.Cli) *cobra.Command {
+	opts := gitkeepOptions{}
+	cmd := &cobra.Command{
+		Use:   "gitkeep [OPTIONS] [DIR]",
+		Short: "Keep empty directories in git",
+		Long:  "Add a .gitkeep placeholder to every empty directory below DIR, and remove stale placeholders from directories that now have other content. Directories ignored by git are skipped.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runGitkeep(cli, opts, dir)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.name, "name", "", "Placeholder file name (default: the config gitkeep name or .gitkeep)")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Print the changes without touching the disk")
+
+	return cmd
+}
+
+func runGitkeep(cli command.Cli, opts gitkeepOptions, dir string) error {
+	name := opts.name
+	if name == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		name = cfg.Gitkeep.Placeholder()
+	}
+
+	changes, err := create.NewCreator().KeepEmptyDirs(dir, name, opts.dryRun)
+	if err != nil {
+		return err
+	}
+
+	var added []create.Result
+	for _, change := range changes {
+		if change.Err != nil {
+			_, _ = fmt.Fprintf(cli.Err(), "failed to update %s: %v\n", change.Path, change.Err)
+			continue
+		}
+		_, _ = fmt.Fprintf(cli.Out(), "%-8s %s\n", change.Action, change.Path)
+		if change.Action == create.KeepAdded {
+			added = append(added, create.Result{Path: change.Path, Type: create.TypeFile, Status: create.StatusCreated})
+		}
+	}
+	if !opts.dryRun && len(added) > 0 {
+		recordRun(cli, added)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/shell"
+	"github.com/spf13/cobra"
+)
+
+type hookOptions struct {
+	shell string
+}
+
+func hookCommand(cli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "hook",
+		Short:  "Commands called by the shell integration",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+	}
+	cmd.AddCommand(hookPromptCommand(cli))
+	return cmd
+}
+
+func hookPromptCommand(cli command.Cli) *cobra.Command {
+	opts := hookOptions{}
+	cmd := &cobra.Command{
+		Use:   "prompt [OPTIONS]",
+		Short: "Print the shell commands to run before the next prompt",
+		Long:  "Called by the prompt hook installed by \"b init\". Prints shell code for the hook to evaluate, or nothing.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			return runHookPrompt(cli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.shell, "shell", "bash", "Shell the output is evaluated by")
+
+	return cmd
+}
+
+// runHookPrompt runs before every prompt, so it must stay quiet and fast.
+// It records the working directory for "b jump", and hands over what
+// burrow could not do from a child process, such as changing the directory
+// of the shell after "b -c".
+func runHookPrompt(cli command.Cli, opts hookOptions) error {
+	if !slices.Contains(shells, opts.shell) {
+		return fmt.Errorf("unsupported shell: %s", opts.shell)
+	}
+	if err := recordVisit(); err != nil {
+		_, _ = fmt.Fprintf(cli.Err(), "failed to record the directory: %v\n", err)
+	}
+
+	dir, err := shell.TakePendingCd()
+	if err != nil || dir == "" {
+		return err
+	}
+	code, err := shell.CdCommand(opts.shell, dir)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cli.Out(), code)
+	return err
+}
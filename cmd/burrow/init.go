@@ -0,0 +1,152 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal"
+	"github.com/elaurentium/burrow/internal/shell"
+	"github.com/spf13/cobra"
+)
+
+// shells are the shells "b init" supports
+var shells = []string{"bash", "zsh", "fish", "pwsh", "nu"}
+
+type initOptions struct {
+	shell.Opts
+	install   bool
+	uninstall bool
+}
+
+func initCommand(cli command.Cli) *cobra.Command {
+	opts := initOptions{Opts: shell.Opts{Hook: shell.Prompt}}
+	cmd := &cobra.Command{
+		Use:       "init [OPTIONS] [SHELL]",
+		Short:     "Print or install the shell integration script",
+		Long:      "Print the script that integrates burrow with your shell, e.g. eval \"$(b init bash)\" in ~/.bashrc. With --install the line is added to the shell's rc file between marker comments, and --uninstall removes it. The shell defaults to the one in $SHELL.",
+		ValidArgs: shells,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			name, err := shellArg(args)
+			if err != nil {
+				return err
+			}
+			switch {
+			case opts.install && opts.uninstall:
+				return errors.New("--install and --uninstall are mutually exclusive")
+			case opts.install:
+				return runInitInstall(cli, cmd, opts, name)
+			case opts.uninstall:
+				return runInitUninstall(cli, name)
+			}
+
+			script, err := internal.Init(name, &opts.Opts)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(cli.Out(), script)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Cmd, "cmd", "", "Name the shell calls burrow by (default: b)")
+	flags.Var(&opts.Hook, "hook", "Hook to install. Values: [none | prompt]")
+	flags.BoolVar(&opts.Echo, "echo", false, "Print what the hook runs before running it")
+	flags.BoolVar(&opts.install, "install", false, "Add the integration to the shell's rc file")
+	flags.BoolVar(&opts.uninstall, "uninstall", false, "Remove the integration from the shell's rc file")
+
+	return cmd
+}
+
+// shellArg returns the shell named on the command line, or the one in $SHELL
+func shellArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	name, err := shell.Detect()
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(shells, name) {
+		return "", fmt.Errorf("unsupported shell: %s", name)
+	}
+	return name, nil
+}
+
+func runInitInstall(cli command.Cli, cmd *cobra.Command, opts initOptions, name string) error {
+	rc, err := shell.RcFile(name)
+	if err != nil {
+		return err
+	}
+
+	// The rc file calls init with the same options, except the install flags
+	var args []string
+	if cmd.Flags().Changed("cmd") {
+		args = append(args, "--cmd", opts.Cmd)
+	}
+	if cmd.Flags().Changed("hook") {
+		args = append(args, "--hook", strings.ToLower(opts.Hook.String()))
+	}
+	if opts.Echo {
+		args = append(args, "--echo")
+	}
+
+	changed, err := shell.Install(rc, shell.Snippet(name, shell.Default(opts.Cmd, "b"), args...))
+	if err != nil {
+		return err
+	}
+	if !changed {
+		_, _ = fmt.Fprintf(cli.Out(), "%s is already set up\n", rc)
+		return nil
+	}
+	_, _ = fmt.Fprintf(cli.Out(), "installed in %s, restart the shell or source it to load burrow\n", rc)
+	return nil
+}
+
+func runInitUninstall(cli command.Cli, name string) error {
+	rc, err := shell.RcFile(name)
+	if err != nil {
+		return err
+	}
+	removed, err := shell.Uninstall(rc)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		_, _ = fmt.Fprintf(cli.Out(), "burrow is not installed in %s\n", rc)
+		return nil
+	}
+	_, _ = fmt.Fprintf(cli.Out(), "removed from %s, a backup is in %s\n", rc, rc+shell.BackupSuffix)
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/cmd/command/streams"
+	"github.com/elaurentium/burrow/internal/shell"
+	"github.com/spf13/cobra"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// testCli is a command.Cli writing to buffers
+type testCli struct {
+	out, err bytes.Buffer
+}
+
+var _ command.Cli = (*testCli)(nil)
+
+func (c *testCli) In() *streams.In        { return streams.NewIn(os.Stdin) }
+func (c *testCli) Out() *streams.Out      { return streams.NewOut(&c.out) }
+func (c *testCli) Err() *streams.Out      { return streams.NewOut(&c.err) }
+func (c *testCli) SetIn(*streams.In)      {}
+func (c *testCli) CurrentVersion() string { return "" }
+
+// execute runs cmd with args and returns what it printed
+func execute(t *testing.T, cmd *cobra.Command, cli *testCli, args ...string) string {
+	t.Helper()
+	cmd.SetArgs(args)
+	cmd.SetOut(&cli.err)
+	cmd.SetErr(&cli.err)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("%v: %v\n%s", args, err, cli.err.String())
+	}
+	return cli.out.String()
+}
+
+// golden compares got with testdata/name, rewriting it with -update
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run go test -update to accept it):\n%s", path, got)
+	}
+}
+
+func TestInitGolden(t *testing.T) {
+	for _, sh := range []string{"bash", "zsh"} {
+		for _, hook := range []string{"none", "prompt"} {
+			for _, cmd := range []string{"", "burrow"} {
+				for _, echo := range []bool{false, true} {
+					args := []string{sh, "--hook", hook}
+					name := sh + "-" + hook
+					if cmd != "" {
+						args = append(args, "--cmd", cmd)
+						name += "-cmd"
+					}
+					if echo {
+						args = append(args, "--echo")
+						name += "-echo"
+					}
+					t.Run(name, func(t *testing.T) {
+						cli := &testCli{}
+						got := execute(t, initCommand(cli), cli, args...)
+						golden(t, filepath.Join("init", name+".golden"), got)
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestHookPrompt(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv(shell.SessionEnv, "4242")
+
+	cli := &testCli{}
+	if got := execute(t, hookPromptCommand(cli), cli, "--shell", "bash"); got != "" {
+		t.Fatalf("no pending cd: got %q, want no output", got)
+	}
+
+	dir := filepath.Join(t.TempDir(), `it's a $dir`)
+	if err := shell.SetPendingCd(dir); err != nil {
+		t.Fatal(err)
+	}
+	cli = &testCli{}
+	got := execute(t, hookPromptCommand(cli), cli, "--shell", "bash")
+	want := "cd -- '" + strings.ReplaceAll(dir, "'", `'\''`) + "'\n"
+	if got != want {
+		t.Fatalf("pending cd: got %q, want %q", got, want)
+	}
+
+	// The pending cd is consumed by the first prompt
+	cli = &testCli{}
+	if got := execute(t, hookPromptCommand(cli), cli, "--shell", "bash"); got != "" {
+		t.Fatalf("after the cd: got %q, want no output", got)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/cmd/prompt"
+	"github.com/elaurentium/burrow/internal/jump"
+	"github.com/elaurentium/burrow/internal/shell"
+	"github.com/spf13/cobra"
+)
+
+type jumpOptions struct {
+	list   bool
+	remove bool
+	first  bool
+}
+
+func jumpCommand(cli command.Cli) *cobra.Command {
+	opts := jumpOptions{}
+	cmd := &cobra.Command{
+		Use:     "jump [OPTIONS] FRAGMENT...",
+		Aliases: []string{"j"},
+		Short:   "Jump to a frequently visited directory",
+		Long:    "Change into the best ranked visited directory whose path contains the fragments in order, the last one in its final component. Directories are recorded by the prompt hook of \"b init\" and ranked by frecency. Without the hook, the directory is printed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			switch {
+			case opts.list:
+				return runJumpList(cli, args)
+			case opts.remove:
+				return runJumpRemove(cli, args)
+			}
+			if len(args) == 0 {
+				return errors.New("nothing to jump to, give a fragment of the directory")
+			}
+			return runJump(cli, opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.list, "list", "l", false, "List the recorded directories matching the fragments, with their score")
+	flags.BoolVar(&opts.remove, "remove", false, "Remove the given directories from the database")
+	flags.BoolVar(&opts.first, "first", false, "Pick the best match without asking when several match")
+
+	return cmd
+}
+
+func runJump(cli command.Cli, opts jumpOptions, fragments []string) error {
+	db, err := jump.Open()
+	if err != nil {
+		return err
+	}
+	matches := db.Query(fragments...)
+	if len(matches) == 0 {
+		return fmt.Errorf("no recorded directory matches %v", fragments)
+	}
+
+	choice := matches[0]
+	if len(matches) > 1 && !opts.first && cli.In().IsTerminal() {
+		shown := matches[:min(len(matches), 9)]
+		options := make([]string, 0, len(shown))
+		for _, m := range shown {
+			options = append(options, m.Path)
+		}
+		i, err := prompt.NewPipe(cli.Err(), cli.In()).Select("Jump to", options)
+		if err != nil {
+			return err
+		}
+		choice = matches[i]
+	}
+
+	db.Visit(choice.Path)
+	if err := db.Save(); err != nil {
+		return err
+	}
+	if err := shell.SetPendingCd(choice.Path); err != nil && !errors.Is(err, shell.ErrNoSession) {
+		return err
+	}
+	_, err = fmt.Fprintln(cli.Out(), choice.Path)
+	return err
+}
+
+func runJumpList(cli command.Cli, fragments []string) error {
+	db, err := jump.Open()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	w := tabwriter.NewWriter(cli.Out(), 0, 4, 2, ' ', 0)
+	for _, d := range db.Query(fragments...) {
+		_, _ = fmt.Fprintf(w, "%.1f\t%s\n", d.Score(now), d.Path)
+	}
+	return w.Flush()
+}
+
+func runJumpRemove(cli command.Cli, dirs []string) error {
+	if len(dirs) == 0 {
+		return errors.New("give the directories to remove")
+	}
+	db, err := jump.Open()
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		if !db.Remove(abs) {
+			_, _ = fmt.Fprintf(cli.Err(), "%s is not recorded\n", abs)
+			continue
+		}
+		_, _ = fmt.Fprintf(cli.Out(), "removed %s\n", abs)
+	}
+	return db.Save()
+}
+
+// recordVisit adds the working directory of the shell to the jump database.
+// The home directory is not worth jumping to and is skipped.
+func recordVisit() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if home, err := os.UserHomeDir(); err == nil && cwd == home {
+		return nil
+	}
+	db, err := jump.Open()
+	if err != nil {
+		return err
+	}
+	if !db.Visit(cwd) {
+		return nil
+	}
+	return db.Save()
+}
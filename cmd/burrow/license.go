@@ -0,0 +1,162 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/config"
+	create "github.com/elaurentium/burrow/internal/fs"
+	"github.com/elaurentium/burrow/internal/license"
+	"github.com/spf13/cobra"
+)
+
+type documentOptions struct {
+	output string
+	holder string
+	year   int
+	force  bool
+	list   bool
+	doc    bool
+}
+
+func licenseCommand(cli command.Cli) *cobra.Command {
+	opts := documentOptions{}
+	names := make([]string, 0, len(license.Documents))
+	for _, doc := range license.Documents {
+		names = append(names, doc.Name)
+	}
+	cmd := &cobra.Command{
+		Use:   "license [OPTIONS] SPDX-ID",
+		Short: "Write a LICENSE file",
+		Long: "Write the text of a common license, filled with the copyright holder and year. Known licenses: " + strings.Join(license.IDs(), ", ") + ".\n\n" +
+			"With --doc, write the skeleton of a well-known top-level document instead. Known documents: " + strings.Join(names, ", ") + ".",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.list {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if opts.list {
+				listDocuments(cli, opts.doc)
+				return nil
+			}
+			var doc license.Document
+			if opts.doc {
+				var err error
+				if doc, err = license.FindDocument(args[0]); err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed("output") {
+					opts.output = doc.File
+				}
+			}
+			data, err := opts.data()
+			if err != nil {
+				return err
+			}
+			var text string
+			if opts.doc {
+				text, err = doc.Text(data)
+			} else {
+				text, err = license.Text(args[0], data)
+			}
+			if err != nil {
+				return err
+			}
+			return writeDocument(cli, opts, text)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.output, "output", "o", "LICENSE", "File to write (default with --doc: the document's usual name)")
+	flags.StringVar(&opts.holder, "holder", "", "Copyright holder (default: the config license holder or git user.name)")
+	flags.IntVar(&opts.year, "year", 0, "Copyright year (default: the current year)")
+	flags.BoolVar(&opts.force, "force", false, "Overwrite the file if it already exists")
+	flags.BoolVarP(&opts.list, "list", "l", false, "List what can be generated")
+	flags.BoolVar(&opts.doc, "doc", false, "Write a CONTRIBUTING or CODE_OF_CONDUCT skeleton instead of a license")
+	return cmd
+}
+
+// listDocuments prints the known license IDs, or the known documents and
+// the files they are written to
+func listDocuments(cli command.Cli, docs bool) {
+	if !docs {
+		for _, id := range license.IDs() {
+			_, _ = fmt.Fprintln(cli.Out(), id)
+		}
+		return
+	}
+	for _, doc := range license.Documents {
+		_, _ = fmt.Fprintf(cli.Out(), "%-16s %s\n", doc.Name, doc.File)
+	}
+}
+
+// data resolves the holder, year and project the document is filled with
+func (o documentOptions) data() (license.Data, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return license.Data{}, err
+	}
+	data := license.Data{Holder: o.holder, Year: o.year, Contact: license.GitEmail()}
+	if data.Holder == "" {
+		data.Holder = cfg.License.Holder
+	}
+	if data.Holder == "" {
+		data.Holder = license.GitHolder()
+	}
+	if data.Year == 0 {
+		data.Year = time.Now().Year()
+	}
+	if data.Contact == "" {
+		data.Contact = "the project maintainers"
+	}
+	if wd, err := os.Getwd(); err == nil {
+		data.Project = filepath.Base(wd)
+	}
+	return data, nil
+}
+
+// writeDocument writes text to the output file. A new file is recorded in
+// the journal so it can be undone; an overwritten one is not.
+func writeDocument(cli command.Cli, opts documentOptions, text string) error {
+	_, statErr := os.Lstat(opts.output)
+	if err := license.Write(opts.output, text, opts.force); err != nil {
+		return err
+	}
+	if os.IsNotExist(statErr) {
+		recordRun(cli, []create.Result{{Path: opts.output, Type: create.TypeFile, Status: create.StatusCreated}})
+	}
+	_, _ = fmt.Fprintln(cli.Out(), opts.output)
+	return nil
+}
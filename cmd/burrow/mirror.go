@@ -0,0 +1,82 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	create "github.com/elaurentium/burrow/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+type mirrorOptions struct {
+	createOptions
+	files    bool
+	truncate int64
+	include  []string
+	exclude  []string
+	maxDepth int
+}
+
+func mirrorCommand(cli command.Cli) *cobra.Command {
+	opts := mirrorOptions{}
+	cmd := &cobra.Command{
+		Use:   "mirror [OPTIONS] SRC DST",
+		Short: "Recreate the directory structure of SRC under DST",
+		Long:  "Recreate the directories of SRC under DST without their contents. With --files every file gets an empty placeholder, and --truncate copies only the first bytes of each file.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runMirror(cli, opts, args[0], args[1])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.files, "files", false, "Create an empty placeholder for every file")
+	flags.Int64Var(&opts.truncate, "truncate", 0, "Copy the first N bytes of every file into its placeholder (implies --files)")
+	flags.StringArrayVar(&opts.include, "include", nil, "Only mirror paths matching this glob (repeatable)")
+	flags.StringArrayVar(&opts.exclude, "exclude", nil, "Skip paths matching this glob (repeatable)")
+	flags.IntVar(&opts.maxDepth, "max-depth", 0, "Only mirror this many levels below SRC (0: no limit)")
+	opts.addFlags(flags)
+
+	return cmd
+}
+
+func runMirror(cli command.Cli, opts mirrorOptions, src, dst string) error {
+	entries, err := create.Mirror(src, dst, create.MirrorOptions{
+		Files:    opts.files,
+		Truncate: opts.truncate,
+		Include:  opts.include,
+		Exclude:  opts.exclude,
+		MaxDepth: opts.maxDepth,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mirror %s: %w", src, err)
+	}
+	return runCreate(cli, opts.createOptions, entries)
+}
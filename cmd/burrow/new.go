@@ -0,0 +1,121 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/cmd/prompt"
+	"github.com/elaurentium/burrow/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+type newOptions struct {
+	createOptions
+	dir  string
+	vars []string
+	yes  bool
+}
+
+func newCommand(cli command.Cli) *cobra.Command {
+	opts := newOptions{}
+	cmd := &cobra.Command{
+		Use:   "new [OPTIONS] TEMPLATE NAME",
+		Short: "Scaffold a project from a template",
+		Long:  "Create a project directory called NAME from a template, rendering file names and contents with the given variables.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runNew(opts, cli, args[0], args[1])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.dir, "dir", "C", ".", "Directory to create the project in")
+	flags.StringArrayVar(&opts.vars, "var", nil, "Set a template variable (key=value, repeatable)")
+	flags.BoolVarP(&opts.yes, "yes", "y", false, "Use default values instead of prompting")
+	opts.createOptions.addFlags(flags)
+
+	return cmd
+}
+
+func runNew(opts newOptions, cli command.Cli, name, project string) error {
+	tmpl, err := scaffold.Find(name)
+	if err != nil {
+		return err
+	}
+
+	vars, err := templateVars(opts, cli, tmpl, project)
+	if err != nil {
+		return err
+	}
+
+	entries, err := tmpl.Render(filepath.ToSlash(filepath.Join(opts.dir, project)), vars)
+	if err != nil {
+		return fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	return runCreate(cli, opts.createOptions, entries)
+}
+
+// templateVars collects the variables from --var flags, prompting for the
+// ones the template declares that were not given
+func templateVars(opts newOptions, cli command.Cli, tmpl *scaffold.Template, project string) (map[string]string, error) {
+	vars := map[string]string{"Name": filepath.Base(project)}
+	for _, kv := range opts.vars {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable %q: expected key=value", kv)
+		}
+		vars[key] = value
+	}
+
+	input := prompt.NewPipe(cli.Out(), cli.In())
+	for _, v := range tmpl.Variables {
+		if _, ok := vars[v.Name]; ok {
+			continue
+		}
+		value, err := scaffold.ResolveDefault(v, vars)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default for %s: %w", v.Name, err)
+		}
+		if !opts.yes {
+			message := v.Prompt
+			if message == "" {
+				message = v.Name
+			}
+			if value, err = input.Input(message, value); err != nil {
+				return nil, fmt.Errorf("failed to read user input: %w", err)
+			}
+		}
+		vars[v.Name] = value
+	}
+	return vars, nil
+}
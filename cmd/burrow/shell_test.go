@@ -0,0 +1,170 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// awkwardDir needs quoting in every shell
+const awkwardDir = `it's a \ $HOME dir`
+
+var (
+	buildOnce sync.Once
+	binary    string
+	buildErr  error
+)
+
+// buildBinary builds b once for the tests that drive it from a real shell
+func buildBinary(t *testing.T) string {
+	t.Helper()
+	buildOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "burrow-test-")
+		if err != nil {
+			buildErr = err
+			return
+		}
+		binary = filepath.Join(dir, "b")
+		if runtime.GOOS == "windows" {
+			binary += ".exe"
+		}
+		out, err := exec.Command("go", "build", "-o", binary, "..").CombinedOutput()
+		if err != nil {
+			buildErr = err
+			t.Logf("%s", out)
+		}
+	})
+	if buildErr != nil {
+		t.Fatalf("failed to build b: %v", buildErr)
+	}
+	return binary
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if binary != "" {
+		_ = os.RemoveAll(filepath.Dir(binary))
+	}
+	os.Exit(code)
+}
+
+// TestShellHookCd sources the init script of each installed shell, leaves a
+// pending cd with "b -c" and runs the prompt hook, which must change the
+// shell into the created directory.
+func TestShellHookCd(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("a backslash is not valid in a Windows file name")
+	}
+
+	tests := []struct {
+		shell  string
+		name   string // executable to look up
+		args   []string
+		script string // %[1]s is the binary, %[2]s the init script file
+	}{
+		{
+			shell: "bash", name: "bash", args: []string{"--norc", "--noprofile", "-c"},
+			script: `source %[2]s; %[1]s -q -c -- "$BURROW_TEST_ARG" && _burrow_hook >/dev/null && pwd`,
+		},
+		{
+			shell: "zsh", name: "zsh", args: []string{"-f", "-c"},
+			script: `source %[2]s; %[1]s -q -c -- "$BURROW_TEST_ARG" && _burrow_hook >/dev/null && pwd`,
+		},
+		{
+			shell: "fish", name: "fish", args: []string{"--no-config", "-c"},
+			script: `source %[2]s; and %[1]s -q -c -- $BURROW_TEST_ARG; and emit fish_prompt; and pwd`,
+		},
+		{
+			shell: "pwsh", name: "pwsh", args: []string{"-NoProfile", "-NonInteractive", "-Command"},
+			script: `. %[2]s; & %[1]s -q -c -- $env:BURROW_TEST_ARG; _BurrowHook | Out-Null; (Get-Location).Path`,
+		},
+		{
+			shell: "nu", name: "nu", args: []string{"-c"},
+			script: `source %[2]s; ^%[1]s -q -c -- $env.BURROW_TEST_ARG; do --env { for hook in $env.config.hooks.pre_prompt { do --env $hook } }; pwd`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			path, err := exec.LookPath(tt.name)
+			if err != nil {
+				t.Skipf("%s is not installed", tt.name)
+			}
+			b := buildBinary(t)
+
+			tmp := t.TempDir()
+			work := filepath.Join(tmp, "work")
+			if err := os.Mkdir(work, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			cli := &testCli{}
+			script := execute(t, initCommand(cli), cli, tt.shell, "--cmd", b)
+			ext := map[string]string{"pwsh": ".ps1", "nu": ".nu"}[tt.shell]
+			initFile := filepath.Join(tmp, "init"+ext)
+			if err := os.WriteFile(initFile, []byte(script), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := exec.Command(path, append(tt.args, fmt.Sprintf(tt.script, b, initFile))...)
+			cmd.Dir = work
+			cmd.Env = append(os.Environ(),
+				"HOME="+tmp,
+				"XDG_CONFIG_HOME="+filepath.Join(tmp, "config"),
+				"XDG_STATE_HOME="+filepath.Join(tmp, "state"),
+				// b expands braces and backslash escapes in its arguments
+				"BURROW_TEST_ARG="+strings.ReplaceAll(awkwardDir, `\`, `\\`)+"/",
+			)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+
+			lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+			got := strings.TrimSpace(lines[len(lines)-1])
+			want := filepath.Join(work, awkwardDir)
+			if !samePath(got, want) {
+				t.Fatalf("pwd after the hook: got %q, want %q\n%s", got, want, out)
+			}
+		})
+	}
+}
+
+// samePath compares two paths once symlinks such as /tmp -> /private/tmp
+// are resolved
+func samePath(a, b string) bool {
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && ra == rb
+}
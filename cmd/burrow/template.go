@@ -0,0 +1,156 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+type templateSaveOptions struct {
+	project     bool
+	description string
+	force       bool
+}
+
+func templateCommand(cli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage scaffolding templates",
+		Long:  "List, inspect and register the templates used by new. Project templates (.burrow/templates) take precedence over user templates ($XDG_CONFIG_HOME/burrow/templates), which take precedence over built-in ones.",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		templateListCommand(cli),
+		templateShowCommand(cli),
+		templateSaveCommand(cli),
+	)
+
+	return cmd
+}
+
+func templateListCommand(cli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List available templates and where they come from",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			all, err := scaffold.All()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cli.Out(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "NAME\tSOURCE\tDESCRIPTION")
+			for _, t := range all {
+				source := string(t.Source)
+				if t.Shadowed {
+					source += " (shadowed)"
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, source, t.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func templateShowCommand(cli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show TEMPLATE",
+		Short: "Show the variables and files of a template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			t, err := scaffold.Find(args[0])
+			if err != nil {
+				return err
+			}
+			files, err := t.Files()
+			if err != nil {
+				return err
+			}
+
+			out := cli.Out()
+			_, _ = fmt.Fprintf(out, "Name:        %s\n", t.Name)
+			_, _ = fmt.Fprintf(out, "Source:      %s\n", t.Source)
+			if t.Dir != "" {
+				_, _ = fmt.Fprintf(out, "Location:    %s\n", t.Dir)
+			}
+			if t.Description != "" {
+				_, _ = fmt.Fprintf(out, "Description: %s\n", t.Description)
+			}
+			if len(t.Variables) > 0 {
+				_, _ = fmt.Fprintln(out, "Variables:")
+				for _, v := range t.Variables {
+					_, _ = fmt.Fprintf(out, "  %s", v.Name)
+					if v.Default != "" {
+						_, _ = fmt.Fprintf(out, " (default: %s)", v.Default)
+					}
+					_, _ = fmt.Fprintln(out)
+				}
+			}
+			_, _ = fmt.Fprintln(out, "Files:")
+			for _, f := range files {
+				_, _ = fmt.Fprintf(out, "  %s\n", f)
+			}
+			return nil
+		},
+	}
+}
+
+func templateSaveCommand(cli command.Cli) *cobra.Command {
+	opts := templateSaveOptions{}
+	cmd := &cobra.Command{
+		Use:   "save [OPTIONS] NAME DIR",
+		Short: "Register an existing directory as a template",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			source := scaffold.SourceUser
+			if opts.project {
+				source = scaffold.SourceProject
+			}
+			dst, err := scaffold.Save(args[0], args[1], source, opts.description, opts.force)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cli.Out(), "Saved %s template %s to %s\n", source, args[0], dst)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.project, "project", false, "Save to the repo-local .burrow/templates instead of the user directory")
+	flags.StringVarP(&opts.description, "description", "d", "", "Description shown by template list")
+	flags.BoolVar(&opts.force, "force", false, "Replace an existing template with the same name")
+
+	return cmd
+}
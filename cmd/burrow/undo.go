@@ -0,0 +1,160 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package burrow
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/elaurentium/burrow/cmd/command"
+	"github.com/elaurentium/burrow/internal/journal"
+	"github.com/elaurentium/burrow/pkg/formatter"
+	"github.com/spf13/cobra"
+)
+
+type undoOptions struct {
+	force bool
+}
+
+type historyOptions struct {
+	limit  int
+	format string
+}
+
+func undoCommand(cli command.Cli) *cobra.Command {
+	opts := undoOptions{}
+	cmd := &cobra.Command{
+		Use:   "undo [OPTIONS] [ID]",
+		Short: "Remove what a previous run created",
+		Long:  "Remove the paths created by the last run, or by the run with the given id from history. Files modified since they were created are kept unless --force is given.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := 0
+			if len(args) > 0 {
+				var err error
+				if id, err = strconv.Atoi(args[0]); err != nil || id <= 0 {
+					return fmt.Errorf("invalid run id: %s", args[0])
+				}
+			}
+			cmd.SilenceUsage = true
+			return runUndo(opts, cli, id)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.force, "force", false, "Remove files even if they were modified after creation")
+
+	return cmd
+}
+
+func runUndo(opts undoOptions, cli command.Cli, id int) error {
+	j, err := journal.Open()
+	if err != nil {
+		return err
+	}
+	defer j.Close()
+	run, err := j.Find(id)
+	if err != nil {
+		return err
+	}
+
+	results, err := journal.Undo(run, opts.force)
+	if err != nil {
+		return err
+	}
+	if err := j.Save(); err != nil {
+		return fmt.Errorf("failed to update the journal: %w", err)
+	}
+
+	for _, r := range results {
+		line := fmt.Sprintf("%-8s %s", r.Action, r.Record.Path)
+		if r.Reason != "" {
+			line += " (" + r.Reason + ")"
+		}
+		_, _ = fmt.Fprintln(cli.Out(), line)
+	}
+	return nil
+}
+
+func historyCommand(cli command.Cli) *cobra.Command {
+	opts := historyOptions{}
+	cmd := &cobra.Command{
+		Use:   "history [OPTIONS]",
+		Short: "List previous runs that can be undone",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runHistory(opts, cli)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVarP(&opts.limit, "limit", "n", 20, "Number of runs to show (0 for all)")
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output. Values: [pretty | json]. (Default: pretty)")
+
+	return cmd
+}
+
+func runHistory(opts historyOptions, cli command.Cli) error {
+	j, err := journal.Open()
+	if err != nil {
+		return err
+	}
+	defer j.Close()
+
+	runs := j.Runs
+	if opts.limit > 0 && len(runs) > opts.limit {
+		runs = runs[len(runs)-opts.limit:]
+	}
+
+	if opts.format == formatter.JSON {
+		if runs == nil {
+			runs = []journal.Run{}
+		}
+		data, err := json.MarshalIndent(runs, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(cli.Out(), string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cli.Out(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTIME\tPATHS\tDIRECTORY\tCOMMAND")
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		id := strconv.Itoa(run.ID)
+		if run.Undone {
+			id += " (undone)"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			id, run.Time.Format("2006-01-02 15:04:05"), len(run.Records), run.Cwd, strings.Join(run.Args, " "))
+	}
+	return w.Flush()
+}
@@ -36,5 +36,6 @@ import (
 func main() {
 	if err := burrow.Execute(); err != nil {
 		os.Args = append([]string{""}, compatibility.Convert(os.Args[1:])...)
+		os.Exit(1)
 	}
 }
@@ -27,19 +27,22 @@
 package prompt
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/elaurentium/burrow/pkg/utils"
 )
 
 type Pipe struct {
 	stdout io.Writer
-	stdin  io.Reader
+	stdin  *bufio.Reader
 }
 
 func NewPipe(stdout io.Writer, stdin io.Reader) *Pipe {
-	return &Pipe{stdout: stdout, stdin: stdin}
+	return &Pipe{stdout: stdout, stdin: bufio.NewReader(stdin)}
 }
 
 func (p Pipe) Confirm(message string, defaultValue bool) (bool, error) {
@@ -48,3 +51,38 @@ func (p Pipe) Confirm(message string, defaultValue bool) (bool, error) {
 	_, _ = fmt.Fscanln(p.stdin, &answer)
 	return utils.StringToBool(answer), nil
 }
+
+// Input asks for a line of text, returning defaultValue when the answer is empty
+func (p Pipe) Input(message, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		message = fmt.Sprintf("%s [%s]", message, defaultValue)
+	}
+	_, _ = fmt.Fprint(p.stdout, message+": ")
+
+	answer, err := p.stdin.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// Select asks to pick one of the options by number and returns its index.
+// An empty answer picks the first option.
+func (p Pipe) Select(message string, options []string) (int, error) {
+	for i, option := range options {
+		_, _ = fmt.Fprintf(p.stdout, "%2d) %s\n", i+1, option)
+	}
+	answer, err := p.Input(message, "1")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(options) {
+		return 0, fmt.Errorf("invalid choice %q, pick a number from 1 to %d", answer, len(options))
+	}
+	return n - 1, nil
+}
@@ -0,0 +1,159 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+
+	pt "github.com/elaurentium/burrow/internal/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the config file in the user config directory and
+// in the project .burrow directory
+const FileName = "config.yaml"
+
+// Config is the user and project configuration of burrow. Values from the
+// project config take precedence over the user config.
+type Config struct {
+	Content  Content  `yaml:"content,omitempty"`
+	License  License  `yaml:"license,omitempty"`
+	Classify Classify `yaml:"classify,omitempty"`
+	Gitkeep  Gitkeep  `yaml:"gitkeep,omitempty"`
+	AutoCd   *bool    `yaml:"autocd,omitempty"` // change into created paths when the shell hook is installed
+}
+
+// DefaultGitkeep is the placeholder file kept in empty directories
+const DefaultGitkeep = ".gitkeep"
+
+// Gitkeep configures the placeholder files of empty directories
+type Gitkeep struct {
+	Enabled *bool  `yaml:"enabled,omitempty"` // add placeholders to new empty directories
+	Name    string `yaml:"name,omitempty"`    // placeholder file name, .gitkeep by default
+}
+
+// Placeholder returns the configured placeholder name
+func (g Gitkeep) Placeholder() string {
+	if g.Name == "" {
+		return DefaultGitkeep
+	}
+	return g.Name
+}
+
+// Classify adds names and patterns to the file-vs-directory classification
+type Classify struct {
+	Files []string `yaml:"files,omitempty"`
+	Dirs  []string `yaml:"dirs,omitempty"`
+}
+
+// License configures the license header of new source files
+type License struct {
+	ID     string `yaml:"id,omitempty"` // SPDX identifier, no header when empty
+	Holder string `yaml:"holder,omitempty"`
+}
+
+// Content configures the starter content of new files
+type Content struct {
+	Empty *bool         `yaml:"empty,omitempty"` // create files empty by default
+	Rules []ContentRule `yaml:"rules,omitempty"`
+}
+
+// ContentRule seeds files matching a name or glob with content
+type ContentRule struct {
+	Match      string `yaml:"match"`
+	Content    string `yaml:"content"`
+	Executable bool   `yaml:"executable,omitempty"`
+}
+
+// Load reads the user config and then the project config, if they exist
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	if dir, err := pt.ConfigDir(); err == nil {
+		if err := cfg.merge(filepath.Join(dir, FileName)); err != nil {
+			return nil, err
+		}
+	}
+	if dir, ok := pt.ProjectDir(); ok {
+		if err := cfg.merge(filepath.Join(dir, FileName)); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// merge overlays the config file at path, if any, on top of cfg
+func (cfg *Config) merge(path string) error {
+	// #nosec G304 - config files live in the user and project burrow directories
+	data, err := os.ReadFile(path)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var layer Config
+	if err := yaml.Unmarshal(data, &layer); err != nil {
+		return fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	if layer.Content.Empty != nil {
+		cfg.Content.Empty = layer.Content.Empty
+	}
+	if layer.License.ID != "" {
+		cfg.License.ID = layer.License.ID
+	}
+	if layer.License.Holder != "" {
+		cfg.License.Holder = layer.License.Holder
+	}
+	if layer.AutoCd != nil {
+		cfg.AutoCd = layer.AutoCd
+	}
+	if layer.Gitkeep.Enabled != nil {
+		cfg.Gitkeep.Enabled = layer.Gitkeep.Enabled
+	}
+	if layer.Gitkeep.Name != "" {
+		cfg.Gitkeep.Name = layer.Gitkeep.Name
+	}
+	// Later layers are more specific, so their rules are tried first
+	cfg.Content.Rules = append(layer.Content.Rules, cfg.Content.Rules...)
+	cfg.Classify.Files = append(layer.Classify.Files, cfg.Classify.Files...)
+	cfg.Classify.Dirs = append(layer.Classify.Dirs, cfg.Classify.Dirs...)
+	return nil
+}
+
+// Bool returns the value of an optional setting, or fallback when unset
+func Bool(value *bool, fallback bool) bool {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
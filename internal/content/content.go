@@ -0,0 +1,126 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package content
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+	pt "github.com/elaurentium/burrow/internal/paths"
+	"github.com/elaurentium/burrow/internal/shell"
+)
+
+// Rule seeds new files whose name matches with starter content. Match is a
+// glob against the base name, such as "*.go" or "Dockerfile", compared
+// case-insensitively.
+type Rule struct {
+	Match      string
+	Content    string
+	Executable bool
+}
+
+// Data is what rule contents are rendered with
+type Data struct {
+	Path    string // path of the new file
+	Name    string // base name without extension
+	Ext     string // extension, with the dot
+	Dir     string // name of the parent directory
+	Project string // name of the working directory
+	Package string // Go package name for the file's directory
+}
+
+// Seed fills in the content of new, empty files from the first matching
+// rule. Executable rules mark the entry so the creator adds the exec bits.
+func Seed(entries []create.Entry, rules []Rule) error {
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Type != create.TypeFile || len(entry.Content) > 0 {
+			continue
+		}
+		rule, ok := match(rules, entry.Path)
+		if !ok {
+			continue
+		}
+
+		text, err := render(rule, dataFor(entry.Path))
+		if err != nil {
+			return fmt.Errorf("content rule %s: %w", rule.Match, err)
+		}
+		entry.Content = []byte(text)
+		entry.Executable = rule.Executable
+	}
+	return nil
+}
+
+func match(rules []Rule, path string) (Rule, bool) {
+	base := strings.ToLower(filepath.Base(path))
+	for _, rule := range rules {
+		if pt.Match(strings.ToLower(rule.Match), base) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func render(rule Rule, data Data) (string, error) {
+	tmpl, err := template.New(rule.Match).Funcs(shell.FuncMap()).Parse(rule.Content)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func dataFor(path string) Data {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	dir := filepath.Base(filepath.Dir(path))
+
+	project := ""
+	if wd, err := os.Getwd(); err == nil {
+		project = filepath.Base(wd)
+	}
+	if dir == "." {
+		dir = project
+	}
+
+	return Data{
+		Path:    path,
+		Name:    strings.TrimSuffix(base, ext),
+		Ext:     ext,
+		Dir:     dir,
+		Project: project,
+		Package: goPackage(path),
+	}
+}
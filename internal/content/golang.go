@@ -0,0 +1,119 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package content
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// goPackage picks the package clause for a new Go file: the package of the
+// Go files already in its directory, "main" in or below a cmd directory of
+// the project, or a name derived from the directory.
+func goPackage(path string) string {
+	dir := filepath.Dir(path)
+	if name, ok := existingPackage(dir); ok {
+		return name
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	// Only the path inside the project counts, wherever it is checked out
+	if rel, err := filepath.Rel(projectRoot(abs), abs); err == nil && !strings.HasPrefix(rel, "..") {
+		for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+			if part == "cmd" {
+				return "main"
+			}
+		}
+	}
+	return sanitizePackage(filepath.Base(abs))
+}
+
+// projectRoot returns the directory of the go.mod closest above dir, or the
+// working directory when there is none
+func projectRoot(dir string) string {
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d
+		}
+		if d == filepath.Dir(d) {
+			break
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return dir
+	}
+	return wd
+}
+
+// existingPackage returns the package of the first non-test Go file in dir
+func existingPackage(dir string) (string, bool) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", false
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return f.Name.Name, true
+		}
+	}
+	return "", false
+}
+
+// sanitizePackage turns a directory name into a valid package name
+func sanitizePackage(name string) string {
+	var buf strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			buf.WriteRune(r)
+		case r == '_':
+			buf.WriteRune(r)
+		}
+	}
+	pkg := buf.String()
+	if pkg == "" || unicode.IsDigit(rune(pkg[0])) {
+		pkg = "pkg" + pkg
+	}
+	return pkg
+}
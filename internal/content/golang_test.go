@@ -0,0 +1,81 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGoPackage(t *testing.T) {
+	// A checkout below a directory named cmd must not make everything main
+	project := filepath.Join(t.TempDir(), "cmd", "proj")
+	if err := os.MkdirAll(filepath.Join(project, "existing"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "existing", "a.go"), []byte("package other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"internal/foo/x.go", "foo"},
+		{"main.go", "proj"},
+		{"cmd/main.go", "main"},
+		{"cmd/tool/main.go", "main"},
+		{"cmd/tool/sub/x.go", "main"},
+		{"existing/b.go", "other"},
+		{"my-lib/x.go", "mylib"},
+		{"2fa/x.go", "pkg2fa"},
+	}
+
+	for _, mod := range []bool{false, true} {
+		name := "without go.mod"
+		if mod {
+			name = "with go.mod"
+			if err := os.WriteFile(filepath.Join(project, "go.mod"), []byte("module proj\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		t.Run(name, func(t *testing.T) {
+			// With a go.mod the working directory does not matter
+			wd := project
+			if mod {
+				wd = filepath.Join(project, "existing")
+			}
+			t.Chdir(wd)
+			for _, tt := range tests {
+				if got := goPackage(filepath.Join(project, tt.path)); got != tt.want {
+					t.Errorf("%s: got %s, want %s", tt.path, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package content
+
+import "github.com/elaurentium/burrow/internal/config"
+
+// Builtin are the default content rules, tried after the configured ones
+var Builtin = []Rule{
+	{Match: "*.go", Content: "package {{.Package}}\n"},
+	{Match: "*.sh", Content: "#!/usr/bin/env bash\nset -euo pipefail\n", Executable: true},
+	{Match: "*.bash", Content: "#!/usr/bin/env bash\nset -euo pipefail\n", Executable: true},
+	{Match: "*.py", Content: "#!/usr/bin/env python3\n", Executable: true},
+	{Match: "*.html", Content: htmlSkeleton},
+	{Match: "Dockerfile", Content: "FROM alpine:3\n\nWORKDIR /app\nCOPY . .\n\nCMD [\"./{{.Project}}\"]\n"},
+	{Match: "Containerfile", Content: "FROM alpine:3\n\nWORKDIR /app\nCOPY . .\n\nCMD [\"./{{.Project}}\"]\n"},
+	{Match: "Makefile", Content: ".PHONY: all build test clean\n\nall: build\n\nbuild:\n\ntest:\n\nclean:\n"},
+	{Match: "README.md", Content: "# {{.Dir}}\n"},
+}
+
+const htmlSkeleton = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{Title .Name}}</title>
+</head>
+<body>
+</body>
+</html>
+`
+
+// Rules returns the configured rules followed by the builtin ones
+func Rules(cfg *config.Config) []Rule {
+	rules := make([]Rule, 0, len(cfg.Content.Rules)+len(Builtin))
+	for _, rule := range cfg.Content.Rules {
+		rules = append(rules, Rule{Match: rule.Match, Content: rule.Content, Executable: rule.Executable})
+	}
+	return append(rules, Builtin...)
+}
@@ -0,0 +1,151 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/elaurentium/burrow/internal/helper"
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+// Classifier decides whether a path argument names a file or a directory.
+// Rules are tried in order:
+//
+//  0. "link=>target" makes a symlink, or a hardlink with Hardlinks
+//  1. a trailing separator makes a directory
+//  2. a name or pattern from Dirs makes a directory
+//  3. a name or pattern from Files makes a file
+//  4. a well-known name without extension, such as Makefile, is a file
+//  5. a version name, such as v1.2, is a directory
+//  6. a name with an extension is a file
+//  7. anything else is a directory
+type Classifier struct {
+	Dirs  []string // names and patterns always created as directories
+	Files []string // names and patterns always created as files
+
+	Hardlinks bool // "link=>target" arguments make hard rather than symbolic links
+}
+
+// LinkSeparator separates a link from its target in a path argument
+const LinkSeparator = "=>"
+
+// Classification is the type of a path and the rule that decided it
+type Classification struct {
+	Path string    `json:"path"`
+	Type EntryType `json:"type"`
+	Rule string    `json:"rule"`
+}
+
+// Classify returns the type of path and the rule that matched
+func (c *Classifier) Classify(path string) Classification {
+	result := func(t EntryType, rule string, args ...any) Classification {
+		return Classification{Path: path, Type: t, Rule: fmt.Sprintf(rule, args...)}
+	}
+
+	if strings.Contains(path, LinkSeparator) {
+		if c.Hardlinks {
+			return result(TypeHardlink, "link syntax %s with --hardlink", LinkSeparator)
+		}
+		return result(TypeSymlink, "link syntax %s", LinkSeparator)
+	}
+	if strings.HasSuffix(path, "/") || strings.HasSuffix(path, string(filepath.Separator)) {
+		return result(TypeDir, "trailing separator")
+	}
+	name := filepath.ToSlash(filepath.Clean(path))
+	if pattern, ok := pt.MatchFirst(c.Dirs, name); ok {
+		return result(TypeDir, "directory pattern %q", pattern)
+	}
+	if pattern, ok := pt.MatchFirst(c.Files, name); ok {
+		return result(TypeFile, "file pattern %q", pattern)
+	}
+
+	base := filepath.Base(path)
+	for _, file := range helper.FilesWithoutExtension {
+		if strings.EqualFold(base, file) {
+			return result(TypeFile, "known file name %s", file)
+		}
+	}
+
+	if isVersion(base) {
+		return result(TypeDir, "version name")
+	}
+	if ext := filepath.Ext(base); ext != "" {
+		return result(TypeFile, "extension %s", ext)
+	}
+	return result(TypeDir, "no extension")
+}
+
+// EntriesFor expands and classifies path arguments. An argument that fails
+// to expand fails them all.
+func (c *Classifier) EntriesFor(args []string) ([]Entry, error) {
+	paths, err := pt.ExpandAll(args)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(paths))
+	for _, path := range paths {
+		if link, target, ok := strings.Cut(path, LinkSeparator); ok {
+			entries = append(entries, LinkFor(link, target, c.Hardlinks))
+			continue
+		}
+		entries = append(entries, Entry{Path: path, Type: c.Classify(path).Type})
+	}
+	return entries, nil
+}
+
+// EntriesOf expands path arguments into entries of the given type,
+// bypassing classification
+func EntriesOf(args []string, t EntryType) ([]Entry, error) {
+	paths, err := pt.ExpandAll(args)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(paths))
+	for _, path := range paths {
+		entries = append(entries, Entry{Path: path, Type: t})
+	}
+	return entries, nil
+}
+
+// isVersion reports whether a name is a v followed by dotted numbers, such
+// as v1.2 or v2.0.1. Bare numbers are left alone so man pages like ls.1 and
+// rotated logs like app.log.1 stay files.
+func isVersion(name string) bool {
+	rest, ok := strings.CutPrefix(strings.ToLower(name), "v")
+	if !ok || rest == "" {
+		return false
+	}
+	for _, part := range strings.Split(rest, ".") {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
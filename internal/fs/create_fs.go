@@ -27,45 +27,357 @@
 package fs
 
 import (
+	"errors"
 	"fmt"
+	iofs "io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
-
-	pt "github.com/elaurentium/burrow/internal/paths"
 )
 
 type Creator struct {
-	Perm    os.FileMode
-	Workers int
-	Wg      *sync.WaitGroup
+	FileMode  os.FileMode // default file permissions, subject to the umask
+	DirMode   os.FileMode // default directory permissions, subject to the umask
+	FileChmod bool        // apply FileMode exactly, ignoring the umask
+	DirChmod  bool        // apply DirMode exactly, ignoring the umask
+	Uid       int         // owner of created paths, -1 leaves it unchanged
+	Gid       int         // group of created paths, -1 leaves it unchanged
+	Workers   int
+	Wg        *sync.WaitGroup
+	Atomic    bool   // on the first failure, remove everything created so far
+	Strict    bool   // fail dangling symlinks instead of warning about them
+	Gitkeep   string // placeholder file added to created directories left empty, "" adds none
 }
 
 func NewCreator() *Creator {
 	return &Creator{
-		Perm:    0755,
-		Workers: 0,
-		Wg:      &sync.WaitGroup{},
+		FileMode: 0644,
+		DirMode:  0755,
+		Uid:      -1,
+		Gid:      -1,
+		Workers:  0,
+		Wg:       &sync.WaitGroup{},
 	}
 }
 
-func (c *Creator) Create(paths []string) error {
-	for _, path := range paths {
-		parent := filepath.Dir(path)
-		if parent != "." && parent != "" {
-			if err := os.MkdirAll(parent, c.Perm); err != nil {
-				fmt.Fprintf(os.Stderr, "error creating directory %s: %v\n", parent, err)
-				continue
+func (c *Creator) Create(args []string) error {
+	entries, err := EntriesFor(args)
+	if err != nil {
+		return err
+	}
+	return c.CreateEntries(entries)
+}
+
+// EntriesFor expands and classifies the path arguments of the root command
+// with the builtin rules
+func EntriesFor(args []string) ([]Entry, error) {
+	var c Classifier
+	return c.EntriesFor(args)
+}
+
+// CreateEntries creates every entry in order, reporting failures on stderr
+func (c *Creator) CreateEntries(entries []Entry) error {
+	results, err := c.Run(entries)
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", result.Err)
+		}
+	}
+	return err
+}
+
+// Run creates every entry in order and returns what happened to each path,
+// including the parent directories that had to be created. In atomic mode
+// the first failure stops the run, everything created is removed, the
+// results end at that failure and it is returned. With more than one
+// worker the parallel engine is used; in atomic mode it rolls back after
+// all workers finish. Both engines return the same results. Links are made
+// or checked once everything else is created, so a link may be listed
+// before its target.
+func (c *Creator) Run(entries []Entry) ([]Result, error) {
+	var results []Result
+	if c.Workers > 1 {
+		results = c.runParallel(entries)
+	} else {
+		results = c.runSequential(entries)
+	}
+
+	c.checkLinks(results)
+	results = c.keepEmpty(results)
+	if c.Atomic {
+		for i, failed := range results {
+			if failed.Err != nil {
+				rolledBack := rollback(results)
+				return results[:i+1], fmt.Errorf("%s: %w (rolled back %d created path(s))", failed.Path, failed.Err, rolledBack)
+			}
+		}
+	}
+	return results, nil
+}
+
+// runSequential creates the entries one at a time. Hardlinks are made after
+// every other entry, as the parallel engine does, so their target may be
+// listed after them; their parent directories are still created in order.
+// In atomic mode it stops at the first failure.
+func (c *Creator) runSequential(entries []Entry) []Result {
+	slots := make([][]Result, len(entries))
+	var links []int
+	linkAll := func() {
+		for _, i := range links {
+			if last := slots[i][len(slots[i])-1]; last.Status == StatusCreated && last.Type == TypeHardlink {
+				slots[i][len(slots[i])-1] = c.createLink(entries[i], last)
 			}
 		}
-		if pt.IsFile(path) {
-			f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL, c.Perm)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+
+	for i, entry := range entries {
+		if entry.Type == TypeHardlink {
+			slots[i] = c.linkParents(entry)
+			links = append(links, i)
+		} else {
+			slots[i] = c.createEntry(entry)
+		}
+		if c.Atomic && slots[i][len(slots[i])-1].Err != nil {
+			// links listed before the failure would have been made
+			linkAll()
+			return slices.Concat(slots[:i+1]...)
+		}
+	}
+	linkAll()
+	return slices.Concat(slots...)
+}
+
+// linkParents creates the parent directories of a hardlink and returns
+// their results followed by a pending result for the link itself
+func (c *Creator) linkParents(entry Entry) []Result {
+	path := filepath.Clean(entry.Path)
+	results, err := c.mkdirAll(filepath.Dir(path))
+	if err != nil {
+		return c.applyOwner(append(results, Result{Path: path, Type: entry.Type, Status: StatusFailed, Err: err}))
+	}
+	return append(c.applyOwner(results), Result{Path: path, Type: entry.Type, Status: StatusCreated, Target: entry.Target})
+}
+
+// createLink makes the hardlink whose pending result linkParents returned
+func (c *Creator) createLink(entry Entry, result Result) Result {
+	mode, exact := c.modeOf(entry)
+	if err := c.createFile(entry, mode, exact); err != nil {
+		result.Status, result.Err = StatusFailed, err
+		if errors.Is(err, iofs.ErrExist) {
+			result.Status = StatusExisted
+		}
+	}
+	return result
+}
+
+// keepEmpty adds the Gitkeep placeholder to every directory created by the
+// run that is still empty
+func (c *Creator) keepEmpty(results []Result) []Result {
+	if c.Gitkeep == "" {
+		return results
+	}
+	for _, r := range results {
+		if r.Type != TypeDir || r.Status != StatusCreated {
+			continue
+		}
+		if children, err := os.ReadDir(r.Path); err != nil || len(children) > 0 {
+			continue
+		}
+		entry := Entry{Path: filepath.Join(r.Path, c.Gitkeep), Type: TypeFile}
+		result := Result{Path: entry.Path, Type: TypeFile, Status: StatusCreated}
+		mode, exact := c.modeOf(entry)
+		if err := c.createFile(entry, mode, exact); err != nil {
+			result.Status, result.Err = StatusFailed, err
+		}
+		results = append(results, c.applyOwner([]Result{result})...)
+	}
+	return results
+}
+
+// checkLinks warns about created symlinks whose target does not exist. In
+// strict mode such links are removed again and fail instead.
+func (c *Creator) checkLinks(results []Result) {
+	for i, r := range results {
+		if r.Type != TypeSymlink || r.Status != StatusCreated {
+			continue
+		}
+		if _, err := os.Stat(r.Path); err == nil {
+			continue
+		}
+		if !c.Strict {
+			results[i].Warning = "dangling symlink, " + r.Target + " does not exist"
+			continue
+		}
+		results[i].Status = StatusFailed
+		results[i].Err = fmt.Errorf("dangling symlink to %s", r.Target)
+		if err := os.Remove(r.Path); err != nil {
+			results[i].Err = fmt.Errorf("%w, failed to remove it: %v", results[i].Err, err)
+		}
+	}
+}
+
+// rollback removes the created paths in reverse order, marking them as
+// rolled back, and returns how many were removed
+func rollback(results []Result) int {
+	removed := 0
+	for i := len(results) - 1; i >= 0; i-- {
+		if results[i].Status != StatusCreated {
+			continue
+		}
+		if err := os.Remove(results[i].Path); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to roll back %s: %v\n", results[i].Path, err)
+			continue
+		}
+		results[i].Status = StatusRolledBack
+		removed++
+	}
+	return removed
+}
+
+func (c *Creator) createEntry(entry Entry) []Result {
+	path := filepath.Clean(entry.Path)
+	mode, exact := c.modeOf(entry)
+
+	if entry.Type == TypeDir {
+		results, err := c.mkdirAll(path)
+		if err != nil {
+			return c.applyOwner(append(results, Result{Path: path, Type: TypeDir, Status: StatusFailed, Err: err}))
+		}
+		if len(results) == 0 || results[len(results)-1].Path != path {
+			results = append(results, Result{Path: path, Type: TypeDir, Status: StatusExisted})
+		} else if exact {
+			if err := os.Chmod(path, mode); err != nil {
+				results[len(results)-1].Err = err
+				results[len(results)-1].Status = StatusFailed
+			}
+		}
+		return c.applyOwner(results)
+	}
+
+	results, err := c.mkdirAll(filepath.Dir(path))
+	if err != nil {
+		return c.applyOwner(append(results, Result{Path: path, Type: entry.Type, Status: StatusFailed, Err: err}))
+	}
+
+	result := Result{Path: path, Type: entry.Type, Status: StatusCreated, Target: entry.Target}
+	if err := c.createFile(entry, mode, exact); err != nil {
+		result.Status, result.Err = StatusFailed, err
+		if errors.Is(err, iofs.ErrExist) {
+			result.Status = StatusExisted
+		}
+	}
+	return c.applyOwner(append(results, result))
+}
+
+// modeOf returns the permissions an entry is created with, and whether they
+// are applied exactly rather than through the umask. Modes set on the entry
+// itself, such as those of a manifest, are always exact.
+func (c *Creator) modeOf(entry Entry) (os.FileMode, bool) {
+	switch {
+	case entry.Mode != 0:
+		return entry.Mode, true
+	case entry.Type == TypeDir:
+		return c.DirMode, c.DirChmod
+	case entry.Executable:
+		// executable by whoever may read it
+		return c.FileMode | (c.FileMode&0444)>>2, c.FileChmod
+	default:
+		return c.FileMode, c.FileChmod
+	}
+}
+
+// mkdirAll creates dir and its missing parents one at a time, returning a
+// result for each directory that did not exist before. On failure the
+// directories created so far are returned with the error, so they are
+// journaled and rolled back like any other.
+func (c *Creator) mkdirAll(dir string) ([]Result, error) {
+	if dir == "." || dir == "" {
+		return nil, nil
+	}
+
+	missing := missingDirs(dir)
+	if len(missing) == 0 {
+		// dir exists, MkdirAll checks that it is a directory
+		if err := os.MkdirAll(dir, c.DirMode); err != nil {
+			return nil, fmt.Errorf("error creating directory %s: %v", dir, err)
+		}
+		return nil, nil
+	}
+
+	results := make([]Result, 0, len(missing))
+	for _, d := range missing {
+		if err := os.Mkdir(d, c.DirMode); err != nil {
+			// Another process may have created it in the meantime
+			if info, statErr := os.Stat(d); statErr == nil && info.IsDir() {
 				continue
 			}
+			return results, fmt.Errorf("error creating directory %s: %v", d, err)
+		}
+		result := Result{Path: d, Type: TypeDir, Status: StatusCreated}
+		if c.DirChmod {
+			if err := os.Chmod(d, c.DirMode); err != nil {
+				result.Status, result.Err = StatusFailed, err
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (c *Creator) createFile(entry Entry, mode os.FileMode, exact bool) error {
+	switch entry.Type {
+	case TypeSymlink:
+		return os.Symlink(entry.Target, entry.Path)
+	case TypeHardlink:
+		return os.Link(entry.Target, entry.Path)
+	}
+
+	f, err := os.OpenFile(entry.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if exact {
+		if err := f.Chmod(mode); err != nil {
 			f.Close()
+			return err
+		}
+	}
+	if len(entry.Content) > 0 {
+		if _, err := f.Write(entry.Content); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// applyOwner changes the owner of the created paths when one is set.
+// Hardlinks share the inode of an existing file and keep its owner.
+func (c *Creator) applyOwner(results []Result) []Result {
+	if c.Uid < 0 && c.Gid < 0 {
+		return results
+	}
+	for i, r := range results {
+		if r.Status != StatusCreated || r.Type == TypeHardlink {
+			continue
+		}
+		if err := os.Lchown(r.Path, c.Uid, c.Gid); err != nil {
+			results[i].Status, results[i].Err = StatusFailed, err
+		}
+	}
+	return results
+}
+
+// missingDirs returns dir and those of its parents that do not exist yet,
+// outermost first
+func missingDirs(dir string) []string {
+	var missing []string
+	for d := dir; d != "." && d != filepath.Dir(d); d = filepath.Dir(d) {
+		if _, err := os.Lstat(d); err == nil {
+			break
 		}
+		missing = append([]string{d}, missing...)
 	}
-	return nil
+	return missing
 }
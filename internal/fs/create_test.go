@@ -0,0 +1,242 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// outcome is the engine independent part of a Result; error messages may
+// be worded differently
+type outcome struct {
+	Path    string
+	Type    EntryType
+	Status  Status
+	Failed  bool
+	Warning string
+	Target  string
+}
+
+func outcomes(results []Result) []outcome {
+	out := make([]outcome, len(results))
+	for i, r := range results {
+		out[i] = outcome{r.Path, r.Type, r.Status, r.Err != nil, r.Warning, r.Target}
+	}
+	return out
+}
+
+// runIn runs the creator in a fresh directory prepared by setup
+func runIn(t *testing.T, c *Creator, setup func(t *testing.T), entries []Entry) ([]Result, error) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if setup != nil {
+		setup(t)
+	}
+	return c.Run(entries)
+}
+
+// created lists what the run left in the working directory, besides the
+// files written by the test setup
+func created(t *testing.T) []string {
+	t.Helper()
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Name() != "old.go" {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+func writeFile(name string) func(t *testing.T) {
+	return func(t *testing.T) {
+		if err := os.WriteFile(name, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRunEnginesAgree(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T)
+		entries []Entry
+		atomic  bool
+	}{
+		{
+			name: "nested",
+			entries: []Entry{
+				{Path: "a/b/c.go", Type: TypeFile},
+				{Path: "a/b", Type: TypeDir},
+				{Path: "a/d/", Type: TypeDir},
+				{Path: "x", Type: TypeDir},
+				{Path: "a/b/e.go", Type: TypeFile},
+			},
+		},
+		{
+			name: "duplicate files",
+			entries: []Entry{
+				{Path: "dup.go", Type: TypeFile},
+				{Path: "dir/dup.go", Type: TypeFile},
+				{Path: "./dup.go", Type: TypeFile},
+				{Path: "dir/dup.go", Type: TypeFile},
+			},
+		},
+		{
+			name:  "existing file",
+			setup: writeFile("old.go"),
+			entries: []Entry{
+				{Path: "new.go", Type: TypeFile},
+				{Path: "old.go", Type: TypeFile},
+			},
+		},
+		{
+			name:  "failed parent",
+			setup: writeFile("blocker"),
+			entries: []Entry{
+				{Path: "blocker/a.go", Type: TypeFile},
+				{Path: "blocker/sub/b.go", Type: TypeFile},
+				{Path: "blocker/dir", Type: TypeDir},
+				{Path: "fine.go", Type: TypeFile},
+			},
+		},
+		{
+			name: "name too long below a new directory",
+			entries: []Entry{
+				{Path: "newdir/" + strings.Repeat("n", 300) + "/x.txt", Type: TypeFile},
+				{Path: "other.txt", Type: TypeFile},
+			},
+		},
+		{
+			name:   "atomic rollback of the parents of a name too long",
+			atomic: true,
+			entries: []Entry{
+				{Path: "newdir/" + strings.Repeat("n", 300) + "/x.txt", Type: TypeFile},
+			},
+		},
+		{
+			name: "hardlink before target",
+			entries: []Entry{
+				{Path: "links/hard.txt", Type: TypeHardlink, Target: "data/target.txt"},
+				{Path: "data/target.txt", Type: TypeFile, Content: []byte("data")},
+				{Path: "soft", Type: TypeSymlink, Target: "data/target.txt"},
+				{Path: "dangling", Type: TypeSymlink, Target: "missing"},
+			},
+		},
+		{
+			name:   "atomic rollback",
+			setup:  writeFile("old.go"),
+			atomic: true,
+			entries: []Entry{
+				{Path: "a/b/c.go", Type: TypeFile},
+				{Path: "old.go", Type: TypeFile},
+				{Path: "d/e.go", Type: TypeFile},
+			},
+		},
+		{
+			name:   "atomic rollback of a hardlink",
+			setup:  writeFile("old.go"),
+			atomic: true,
+			entries: []Entry{
+				{Path: "hard.txt", Type: TypeHardlink, Target: "target.txt"},
+				{Path: "target.txt", Type: TypeFile},
+				{Path: "old.go", Type: TypeFile},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []outcome
+			var wantErr bool
+			for _, workers := range []int{0, 4} {
+				t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+					c := NewCreator()
+					c.Workers, c.Atomic = workers, tt.atomic
+					results, err := runIn(t, c, tt.setup, tt.entries)
+					if tt.atomic != (err != nil) {
+						t.Fatalf("atomic %v: unexpected error %v", tt.atomic, err)
+					}
+					got := outcomes(results)
+					if tt.atomic {
+						if leftover := created(t); len(leftover) > 0 {
+							t.Errorf("atomic run left %v behind", leftover)
+						}
+					}
+					if workers == 0 {
+						want, wantErr = got, err != nil
+						return
+					}
+					if (err != nil) != wantErr || !reflect.DeepEqual(got, want) {
+						t.Errorf("parallel engine differs from the sequential one:\ngot  %+v\nwant %+v", got, want)
+					}
+				})
+			}
+		})
+	}
+}
+
+// tree returns about n entries spread over nested directories, as a large
+// scaffold would
+func tree(n int) []Entry {
+	entries := make([]Entry, 0, n)
+	for i := 0; len(entries) < n; i++ {
+		dir := filepath.Join(fmt.Sprintf("pkg%02d", i%50), fmt.Sprintf("mod%03d", i))
+		entries = append(entries, Entry{Path: dir, Type: TypeDir})
+		for j := 0; j < 9 && len(entries) < n; j++ {
+			entries = append(entries, Entry{Path: filepath.Join(dir, fmt.Sprintf("file%d.go", j)), Type: TypeFile})
+		}
+	}
+	return entries
+}
+
+func BenchmarkCreate(b *testing.B) {
+	entries := tree(10000)
+	for _, workers := range []int{0, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				b.StopTimer()
+				b.Chdir(b.TempDir())
+				c := NewCreator()
+				c.Workers = workers
+				b.StartTimer()
+				if _, err := c.Run(entries); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+//go:build unix
+
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+// TestExactModes checks that an exact mode for one type of entry leaves
+// the other subject to the umask
+func TestExactModes(t *testing.T) {
+	old := syscall.Umask(0o077)
+	defer syscall.Umask(old)
+
+	tests := []struct {
+		name              string
+		fileMode          os.FileMode
+		dirMode           os.FileMode
+		fileChmod         bool
+		dirChmod          bool
+		wantFile, wantDir os.FileMode
+	}{
+		{"umask only", 0o644, 0o755, false, false, 0o600, 0o700},
+		{"exact files", 0o640, 0o755, true, false, 0o640, 0o700},
+		{"exact directories", 0o644, 0o750, false, true, 0o600, 0o750},
+		{"both exact", 0o640, 0o750, true, true, 0o640, 0o750},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			c := NewCreator()
+			c.FileMode, c.DirMode = tt.fileMode, tt.dirMode
+			c.FileChmod, c.DirChmod = tt.fileChmod, tt.dirChmod
+			if _, err := c.Run([]Entry{{Path: "dir/file", Type: TypeFile}}); err != nil {
+				t.Fatal(err)
+			}
+			for path, want := range map[string]os.FileMode{"dir/file": tt.wantFile, "dir": tt.wantDir} {
+				info, err := os.Stat(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := info.Mode().Perm(); got != want {
+					t.Errorf("%s: got %o, want %o", path, got, want)
+				}
+			}
+		})
+	}
+}
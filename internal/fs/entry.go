@@ -0,0 +1,76 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// EntryType is the kind of filesystem object an Entry describes
+type EntryType string
+
+const (
+	TypeDir      EntryType = "dir"
+	TypeFile     EntryType = "file"
+	TypeSymlink  EntryType = "symlink"
+	TypeHardlink EntryType = "hardlink"
+)
+
+// Entry is a single filesystem object to be created
+type Entry struct {
+	Path       string      // path relative to the working directory
+	Type       EntryType   // dir, file or symlink
+	Content    []byte      // initial file content
+	Mode       os.FileMode // permissions, zero means the Creator default
+	Target     string      // symlink target as stored in the link, or hardlink target path
+	Executable bool        // add exec bits to the Creator default file mode where it is readable
+}
+
+// LinkFor returns a link entry for the "link=>target" syntax, where both
+// paths are relative to the working directory. Relative symlink targets
+// are rewritten relative to the directory of the link.
+func LinkFor(link, target string, hard bool) Entry {
+	link = filepath.Clean(link)
+	target = filepath.Clean(target)
+	if hard {
+		return Entry{Path: link, Type: TypeHardlink, Target: target}
+	}
+	if !filepath.IsAbs(target) {
+		if rel, err := filepath.Rel(filepath.Dir(link), target); err == nil {
+			target = rel
+		}
+	}
+	return Entry{Path: link, Type: TypeSymlink, Target: target}
+}
+
+// EntryFor classifies a plain path argument as a file or a directory with
+// the builtin rules
+func EntryFor(path string) Entry {
+	var c Classifier
+	return Entry{Path: path, Type: c.Classify(path).Type}
+}
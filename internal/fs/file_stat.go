@@ -28,6 +28,7 @@ package fs
 
 import (
 	"fmt"
+	"os"
 	"os/user"
 	"strconv"
 	"strings"
@@ -67,6 +68,37 @@ type Stat struct {
 	Path    string    // file path
 }
 
+// Type returns the entry type of the file, or an empty type for special
+// files such as devices, pipes and sockets.
+func (s Stat) Type() EntryType {
+	switch s.Mode & S_IFMT {
+	case S_IFDIR:
+		return TypeDir
+	case S_IFREG:
+		return TypeFile
+	case S_IFLNK:
+		return TypeSymlink
+	default:
+		return ""
+	}
+}
+
+// Perm returns the permission bits of the file, with the setuid, setgid
+// and sticky bits as their os.FileMode flags
+func (s Stat) Perm() os.FileMode {
+	mode := os.FileMode(s.Mode & 0o777)
+	if s.Mode&0o4000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if s.Mode&0o2000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if s.Mode&0o1000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
 // FormatPermissions converts a file mode to a string like "drwxr-xr-x"
 func formatPermissions(mode uint32) string {
 	var buf strings.Builder
@@ -0,0 +1,110 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+// KeepAction is what KeepEmptyDirs does to a placeholder
+type KeepAction string
+
+const (
+	KeepAdded   KeepAction = "added"   // placeholder added to an empty directory
+	KeepRemoved KeepAction = "removed" // stale placeholder removed from a directory with content
+)
+
+// KeepChange is a placeholder added or removed by KeepEmptyDirs
+type KeepChange struct {
+	Path   string
+	Action KeepAction
+	Err    error
+}
+
+// KeepEmptyDirs walks root and adds the placeholder file name to every empty
+// directory, and removes it from directories that now have other content.
+// Directories ignored by git are left alone. With dryRun nothing is
+// changed on disk.
+func (c *Creator) KeepEmptyDirs(root, name string, dryRun bool) ([]KeepChange, error) {
+	ignore := pt.NewGitIgnore(root)
+
+	var changes []KeepChange
+	err := filepath.WalkDir(root, func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.Name() == ".git" || (rel != "." && ignore.Ignored(rel, true)) {
+			return filepath.SkipDir
+		}
+		ignore.Load(rel)
+
+		children, err := os.ReadDir(p)
+		if err != nil {
+			return err
+		}
+		placeholder := filepath.Join(p, name)
+		switch {
+		case len(children) == 0:
+			change := KeepChange{Path: placeholder, Action: KeepAdded}
+			if !dryRun {
+				entry := Entry{Path: placeholder, Type: TypeFile}
+				mode, exact := c.modeOf(entry)
+				change.Err = c.createFile(entry, mode, exact)
+			}
+			changes = append(changes, change)
+		case len(children) > 1 && hasChild(children, name):
+			change := KeepChange{Path: placeholder, Action: KeepRemoved}
+			if !dryRun {
+				change.Err = os.Remove(placeholder)
+			}
+			changes = append(changes, change)
+		}
+		return nil
+	})
+	return changes, err
+}
+
+func hasChild(children []iofs.DirEntry, name string) bool {
+	for _, child := range children {
+		if child.Name() == name && child.Type().IsRegular() {
+			return true
+		}
+	}
+	return false
+}
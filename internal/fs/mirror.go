@@ -0,0 +1,143 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	"io"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+// MirrorOptions controls what Mirror copies of a directory
+type MirrorOptions struct {
+	Files    bool     // create a placeholder for every file and symlink
+	Truncate int64    // copy up to this many bytes into each placeholder, 0 leaves them empty
+	Include  []string // only mirror paths matching these globs
+	Exclude  []string // skip paths matching these globs
+	MaxDepth int      // deepest level mirrored below src, 0 means no limit
+}
+
+// Mirror walks src and returns the entries that recreate its directory
+// structure under dst. File contents are not copied beyond Truncate bytes.
+func Mirror(src, dst string, opts MirrorOptions) ([]Entry, error) {
+	skip, _ := filepath.Abs(dst)
+
+	var entries []Entry
+	err := filepath.WalkDir(src, func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		abs, _ := filepath.Abs(p)
+		depth := strings.Count(filepath.ToSlash(rel), "/") + 1
+		if abs == skip || pt.MatchAny(opts.Exclude, filepath.ToSlash(rel)) || (opts.MaxDepth > 0 && depth > opts.MaxDepth) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		entry := Entry{Path: filepath.Join(dst, rel)}
+		switch {
+		case d.IsDir():
+			entry.Type = TypeDir
+		case !opts.Files && opts.Truncate == 0:
+			return nil
+		case d.Type()&iofs.ModeSymlink != 0:
+			entry.Type = TypeSymlink
+			if entry.Target, err = os.Readlink(p); err != nil {
+				return err
+			}
+		case d.Type().IsRegular():
+			entry.Type = TypeFile
+			if entry.Content, err = head(p, opts.Truncate); err != nil {
+				return err
+			}
+		default:
+			// Devices, pipes and sockets cannot be recreated by burrow
+			return nil
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Include) > 0 {
+		entries = includeMirrored(entries, dst, opts.Include)
+	}
+	return entries, nil
+}
+
+// head returns up to n bytes from the start of the file at path
+func head(path string, n int64) ([]byte, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	// #nosec G304 - path comes from walking the mirrored directory
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.LimitReader(f, n))
+}
+
+// includeMirrored keeps the entries matching an include glob, along with
+// the directories leading to them
+func includeMirrored(entries []Entry, dst string, include []string) []Entry {
+	keep := make(map[string]bool)
+	for _, e := range entries {
+		rel, err := filepath.Rel(dst, e.Path)
+		if err != nil || !pt.MatchAny(include, filepath.ToSlash(rel)) {
+			continue
+		}
+		for p := e.Path; p != dst && p != "." && !keep[p]; p = filepath.Dir(p) {
+			keep[p] = true
+		}
+	}
+
+	var out []Entry
+	for _, e := range entries {
+		if keep[e.Path] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,209 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// dirJob is a directory the parallel engine creates exactly once
+type dirJob struct {
+	path   string
+	mode   os.FileMode
+	exact  bool
+	owner  int // index of the first entry that needs the directory
+	result Result
+}
+
+// runParallel creates the entries with c.Workers goroutines. Every
+// directory needed by any entry is created once, one depth level at a time,
+// then files and symlinks are created concurrently, and hardlinks last so
+// their targets exist. Results are returned in
+// the same order and shape as the sequential engine.
+func (c *Creator) runParallel(entries []Entry) []Result {
+	cleaned := make([]string, len(entries))
+	dirs := make(map[string]*dirJob)
+	var order []*dirJob
+
+	for i, entry := range entries {
+		clean := filepath.Clean(entry.Path)
+		cleaned[i] = clean
+
+		needed := parentsOf(clean)
+		if entry.Type == TypeDir {
+			needed = append(needed, clean)
+		}
+		for _, d := range needed {
+			if _, ok := dirs[d]; ok {
+				continue
+			}
+			job := &dirJob{path: d, mode: c.DirMode, exact: c.DirChmod, owner: i}
+			if d == clean {
+				job.mode, job.exact = c.modeOf(entry)
+			}
+			dirs[d] = job
+			order = append(order, job)
+		}
+	}
+
+	// Parents always sit at a lower depth, so each level only needs the
+	// previous one to be complete
+	levels := make(map[int][]*dirJob)
+	var depths []int
+	for _, job := range order {
+		depth := strings.Count(job.path, string(filepath.Separator))
+		if _, ok := levels[depth]; !ok {
+			depths = append(depths, depth)
+		}
+		levels[depth] = append(levels[depth], job)
+	}
+	sort.Ints(depths)
+	for _, depth := range depths {
+		level := levels[depth]
+		c.parallel(len(level), func(k int) {
+			job := level[k]
+			job.result = c.mkdir(job, dirs[filepath.Dir(job.path)])
+		})
+	}
+
+	// Later duplicates of a file would race the first one for O_EXCL, fail
+	// them up front so the outcome does not depend on scheduling
+	fileResults := make([]Result, len(entries))
+	first := make(map[string]bool)
+	var files, links []int
+	for i, entry := range entries {
+		if entry.Type == TypeDir {
+			continue
+		}
+		if first[cleaned[i]] {
+			fileResults[i] = Result{Path: cleaned[i], Type: entry.Type, Status: StatusExisted,
+				Err: &os.PathError{Op: "open", Path: entry.Path, Err: iofs.ErrExist}}
+			continue
+		}
+		first[cleaned[i]] = true
+		if entry.Type == TypeHardlink {
+			links = append(links, i)
+		} else {
+			files = append(files, i)
+		}
+	}
+	createFile := func(i int) {
+		entry := entries[i]
+		result := Result{Path: cleaned[i], Type: entry.Type, Status: StatusCreated, Target: entry.Target}
+		if parent, ok := dirs[filepath.Dir(cleaned[i])]; ok && parent.result.Err != nil {
+			result.Status, result.Err = StatusFailed, parent.result.Err
+			fileResults[i] = result
+			return
+		}
+		mode, exact := c.modeOf(entry)
+		if err := c.createFile(entry, mode, exact); err != nil {
+			result.Status, result.Err = StatusFailed, err
+			if errors.Is(err, iofs.ErrExist) {
+				result.Status = StatusExisted
+			}
+		}
+		fileResults[i] = result
+	}
+	c.parallel(len(files), func(k int) { createFile(files[k]) })
+	c.parallel(len(links), func(k int) { createFile(links[k]) })
+
+	owned := make(map[int][]*dirJob)
+	for _, job := range order {
+		owned[job.owner] = append(owned[job.owner], job)
+	}
+
+	var results []Result
+	for i, entry := range entries {
+		for _, job := range owned[i] {
+			if job.result.Status == StatusCreated {
+				results = append(results, job.result)
+			}
+		}
+
+		if entry.Type != TypeDir {
+			results = append(results, fileResults[i])
+			continue
+		}
+		job := dirs[cleaned[i]]
+		switch {
+		case job.result.Err != nil:
+			results = append(results, Result{Path: cleaned[i], Type: TypeDir, Status: StatusFailed, Err: job.result.Err})
+		case job.owner != i || job.result.Status == StatusExisted:
+			results = append(results, Result{Path: cleaned[i], Type: TypeDir, Status: StatusExisted})
+		}
+	}
+	return c.applyOwner(results)
+}
+
+// mkdir creates a single directory whose parent job, if any, already ran
+func (c *Creator) mkdir(job, parent *dirJob) Result {
+	result := Result{Path: job.path, Type: TypeDir, Status: StatusCreated}
+	if parent != nil && parent.result.Err != nil {
+		result.Status, result.Err = StatusFailed, parent.result.Err
+		return result
+	}
+
+	err := os.Mkdir(job.path, job.mode)
+	if errors.Is(err, iofs.ErrExist) {
+		if info, statErr := os.Stat(job.path); statErr == nil && info.IsDir() {
+			result.Status = StatusExisted
+			return result
+		}
+	}
+	if err == nil && job.exact {
+		err = os.Chmod(job.path, job.mode)
+	}
+	if err != nil {
+		result.Status, result.Err = StatusFailed, fmt.Errorf("error creating directory %s: %v", job.path, err)
+	}
+	return result
+}
+
+// parallel calls fn for every index below n using c.Workers goroutines
+func (c *Creator) parallel(n int, fn func(int)) {
+	jobs := make(chan int)
+	for w := 0; w < min(c.Workers, n); w++ {
+		c.Wg.Add(1)
+		go func() {
+			defer c.Wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	c.Wg.Wait()
+}
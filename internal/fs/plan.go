@@ -0,0 +1,189 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+import (
+	"errors"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Action is what creating an entry would do to the filesystem
+type Action string
+
+const (
+	ActionCreate Action = "create" // the entry would be created
+	ActionExists Action = "exists" // the directory already exists and is kept
+	ActionFail   Action = "fail"   // creating the entry would fail
+)
+
+// PlannedEntry is an entry of a Plan
+type PlannedEntry struct {
+	Path     string    `json:"path"`
+	Type     EntryType `json:"type"`
+	Action   Action    `json:"action"`
+	Implicit bool      `json:"implicit,omitempty"` // parent created by MkdirAll
+	Reason   string    `json:"reason,omitempty"`
+	Target   string    `json:"target,omitempty"`
+}
+
+// Plan computes what CreateEntries would do without touching the disk,
+// including the parent directories MkdirAll would create.
+func (c *Creator) Plan(entries []Entry) []PlannedEntry {
+	var plan []PlannedEntry
+	var links []int
+	planned := make(map[string]PlannedEntry)
+
+	for _, entry := range entries {
+		clean := filepath.Clean(entry.Path)
+
+		dirs := parentsOf(clean)
+		if entry.Type == TypeDir {
+			dirs = append(dirs, clean)
+		}
+
+		failed := false
+		for _, dir := range dirs {
+			if prev, ok := planned[dir]; ok {
+				if prev.Type != TypeDir || prev.Action == ActionFail {
+					failed = true
+					break
+				}
+				continue
+			}
+
+			p := PlannedEntry{Path: dir, Type: TypeDir, Action: ActionCreate, Implicit: dir != clean}
+			// directories are followed like MkdirAll does, so a symlinked
+			// parent is usable; only a dangling link blocks creation
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				p.Action = ActionExists
+			} else if err == nil {
+				p.Action, p.Reason = ActionFail, "exists and is not a directory"
+			} else if !errors.Is(err, iofs.ErrNotExist) {
+				p.Action, p.Reason = ActionFail, err.Error()
+			} else if _, lerr := os.Lstat(dir); lerr == nil {
+				p.Action, p.Reason = ActionFail, "dangling symlink"
+			}
+			planned[dir] = p
+			plan = append(plan, p)
+
+			if p.Action == ActionFail {
+				failed = true
+				break
+			}
+		}
+
+		if entry.Type == TypeDir {
+			continue
+		}
+
+		p := PlannedEntry{Path: clean, Type: entry.Type, Action: ActionCreate, Target: entry.Target}
+		if failed {
+			p.Action, p.Reason = ActionFail, "parent directory cannot be created"
+		} else if _, ok := planned[clean]; ok {
+			p.Action, p.Reason = ActionFail, "listed more than once"
+		} else if _, err := os.Lstat(clean); err == nil {
+			p.Action, p.Reason = ActionFail, "file exists"
+		} else if !errors.Is(err, iofs.ErrNotExist) {
+			p.Action, p.Reason = ActionFail, err.Error()
+		} else if entry.Type == TypeSymlink || entry.Type == TypeHardlink {
+			links = append(links, len(plan))
+		}
+		planned[clean] = p
+		plan = append(plan, p)
+	}
+
+	// Links may be listed before their targets, check them once everything
+	// is planned
+	for _, i := range links {
+		c.planLink(&plan[i], planned)
+	}
+	return c.planGitkeep(plan)
+}
+
+// planGitkeep adds the placeholders of the directories that would be
+// created without any children
+func (c *Creator) planGitkeep(plan []PlannedEntry) []PlannedEntry {
+	if c.Gitkeep == "" {
+		return plan
+	}
+	parents := make(map[string]bool)
+	for _, p := range plan {
+		parents[filepath.Dir(p.Path)] = true
+	}
+	for _, p := range plan {
+		if p.Type == TypeDir && p.Action == ActionCreate && !parents[p.Path] {
+			plan = append(plan, PlannedEntry{Path: filepath.Join(p.Path, c.Gitkeep), Type: TypeFile, Action: ActionCreate})
+		}
+	}
+	return plan
+}
+
+// planLink checks the target of a link entry against the disk and the
+// entries planned so far
+func (c *Creator) planLink(p *PlannedEntry, planned map[string]PlannedEntry) {
+	target := filepath.Clean(p.Target)
+	switch p.Type {
+	case TypeSymlink:
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(p.Path), target)
+		}
+		if _, ok := planned[target]; ok {
+			return
+		}
+		if _, err := os.Stat(target); err != nil {
+			p.Reason = "dangling target " + p.Target
+			if c.Strict {
+				p.Action = ActionFail
+			}
+		}
+	case TypeHardlink:
+		if prev, ok := planned[target]; ok {
+			if prev.Type == TypeDir {
+				p.Action, p.Reason = ActionFail, "target is a directory"
+			}
+			return
+		}
+		info, err := os.Stat(target)
+		switch {
+		case err != nil:
+			p.Action, p.Reason = ActionFail, "target does not exist"
+		case info.IsDir():
+			p.Action, p.Reason = ActionFail, "target is a directory"
+		}
+	}
+}
+
+// parentsOf returns the ancestors of path, outermost first
+func parentsOf(path string) []string {
+	var parents []string
+	for dir := filepath.Dir(path); dir != "." && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		parents = append([]string{dir}, parents...)
+	}
+	return parents
+}
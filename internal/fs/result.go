@@ -0,0 +1,51 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package fs
+
+// Status is the outcome of creating a path
+type Status string
+
+const (
+	StatusCreated Status = "created"
+	StatusExisted Status = "existed"
+	StatusFailed  Status = "failed"
+	// StatusRolledBack marks paths removed again after an atomic run failed
+	StatusRolledBack Status = "rolled back"
+)
+
+// Result records what happened to a single path during a run. Err is set
+// whenever the path could not be created as requested, including files
+// that already existed. Warning is set for paths that were created but
+// need attention, such as dangling symlinks.
+type Result struct {
+	Path    string
+	Type    EntryType
+	Status  Status
+	Err     error
+	Warning string
+	Target  string // target of a created link
+}
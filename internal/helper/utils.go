@@ -45,6 +45,11 @@ const (
 	Jakefile   = "Jakefile"
 	Gruntfile  = "Gruntfile"
 	Gulpfile   = "Gulpfile"
+	Justfile   = "Justfile"
+	Taskfile   = "Taskfile"
+	Earthfile  = "Earthfile"
+	BUILD      = "BUILD"
+	WORKSPACE  = "WORKSPACE"
 
 	// Container/VM
 	Dockerfile    = "Dockerfile"
@@ -89,6 +94,7 @@ var (
 	FilesWithoutExtension = []string{
 		// Build tools
 		Makefile, CMakeLists, Rakefile, Jakefile, Gruntfile, Gulpfile,
+		Justfile, Taskfile, Earthfile, BUILD, WORKSPACE,
 
 		// Container/VM
 		Dockerfile, Containerfile, Vagrantfile,
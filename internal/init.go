@@ -46,6 +46,24 @@ func Init(cmd string, opts *shell.Opts) (string, error) {
 			return "", err
 		}
 		return zsh.Render()
+	case "fish":
+		fish, err := shell.NewFish(opts)
+		if err != nil {
+			return "", err
+		}
+		return fish.Render()
+	case "pwsh":
+		pwsh, err := shell.NewPwsh(opts)
+		if err != nil {
+			return "", err
+		}
+		return pwsh.Render()
+	case "nu":
+		nu, err := shell.NewNushell(opts)
+		if err != nil {
+			return "", err
+		}
+		return nu.Render()
 	default:
 		return "", fmt.Errorf("unsupported shell: %s", cmd)
 	}
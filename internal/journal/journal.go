@@ -0,0 +1,240 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+const (
+	// MaxRuns is how many runs the journal keeps
+	MaxRuns = 200
+	// MaxRecords caps the paths recorded across all runs, which bounds the
+	// size of the journal; the oldest runs are dropped first
+	MaxRecords = 10000
+)
+
+// Record is a path created by a run
+type Record struct {
+	Path          string           `json:"path"` // absolute path
+	Type          create.EntryType `json:"type"`
+	ParentExisted bool             `json:"parent_existed,omitempty"`
+	Hash          string           `json:"hash,omitempty"`   // sha256 of the file right after creation
+	Target        string           `json:"target,omitempty"` // symlink target, or absolute hardlink target
+}
+
+// Run is a single creation run
+type Run struct {
+	ID      int       `json:"id"`
+	Time    time.Time `json:"time"`
+	Cwd     string    `json:"cwd"`
+	Args    []string  `json:"args,omitempty"`
+	Records []Record  `json:"records"`
+	Undone  bool      `json:"undone,omitempty"`
+}
+
+// Journal is the history of creation runs, stored as JSON in the user's
+// state directory
+type Journal struct {
+	path string
+	lock string
+	Runs []Run `json:"runs"`
+}
+
+// Path returns the location of the journal file
+func Path() (string, error) {
+	dir, err := pt.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "journal.json"), nil
+}
+
+// Open locks and reads the journal, returning an empty one if none exists
+// yet. The lock is held until Close.
+func Open() (*Journal, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	j := &Journal{path: path}
+	if j.lock, err = lock(path); err != nil {
+		return nil, err
+	}
+	// #nosec G304 - the journal lives in the user's state directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return j, nil
+	} else if err != nil {
+		_ = j.Close()
+		return nil, err
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		_ = j.Close()
+		return nil, fmt.Errorf("corrupt journal %s: %w", path, err)
+	}
+	return j, nil
+}
+
+// Save writes the journal back, dropping the oldest runs beyond MaxRuns or
+// MaxRecords
+func (j *Journal) Save() error {
+	if len(j.Runs) > MaxRuns {
+		j.Runs = j.Runs[len(j.Runs)-MaxRuns:]
+	}
+	total := 0
+	for i := len(j.Runs) - 1; i >= 0; i-- {
+		total += len(j.Runs[i].Records)
+		if total > MaxRecords {
+			j.Runs = j.Runs[i+1:]
+			break
+		}
+	}
+
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return err
+	}
+	// Write then rename so an interrupted save never truncates the journal
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), "journal-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path)
+}
+
+// Add records the paths a run created and returns the new run. Runs that
+// created nothing are not recorded.
+func (j *Journal) Add(args []string, results []create.Result) (*Run, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	created := make(map[string]bool)
+	run := Run{Time: time.Now(), Cwd: cwd, Args: args}
+	for _, r := range results {
+		if r.Status != create.StatusCreated {
+			continue
+		}
+		abs, err := filepath.Abs(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		record := Record{
+			Path:          abs,
+			Type:          r.Type,
+			ParentExisted: !created[filepath.Dir(abs)],
+		}
+		switch r.Type {
+		case create.TypeFile:
+			if record.Hash, err = HashFile(abs); err != nil {
+				return nil, err
+			}
+		case create.TypeSymlink:
+			if record.Target, err = os.Readlink(abs); err != nil {
+				return nil, err
+			}
+		case create.TypeHardlink:
+			if record.Target, err = filepath.Abs(r.Target); err != nil {
+				return nil, err
+			}
+		}
+		created[abs] = true
+		run.Records = append(run.Records, record)
+	}
+	if len(run.Records) == 0 {
+		return nil, nil
+	}
+	if len(run.Records) > MaxRecords {
+		return nil, fmt.Errorf("run created %d paths, more than the %d the journal keeps, it cannot be undone",
+			len(run.Records), MaxRecords)
+	}
+
+	run.ID = 1
+	if len(j.Runs) > 0 {
+		run.ID = j.Runs[len(j.Runs)-1].ID + 1
+	}
+	j.Runs = append(j.Runs, run)
+	return &j.Runs[len(j.Runs)-1], nil
+}
+
+// Find returns the run with the given id, or the last run that has not been
+// undone when id is zero
+func (j *Journal) Find(id int) (*Run, error) {
+	for i := len(j.Runs) - 1; i >= 0; i-- {
+		run := &j.Runs[i]
+		if (id == 0 && !run.Undone) || run.ID == id {
+			return run, nil
+		}
+	}
+	if id == 0 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+	return nil, fmt.Errorf("no run with id %d in the journal", id)
+}
+
+// HashFile returns the hex encoded sha256 of a file
+func HashFile(path string) (string, error) {
+	// #nosec G304 - hashing files burrow created
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
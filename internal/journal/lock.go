@@ -0,0 +1,89 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package journal
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// lockWait is how long Open waits for another run to release the journal
+	lockWait = 10 * time.Second
+	// lockStale is the age after which a lock left by a crashed run is broken
+	lockStale = time.Minute
+)
+
+// lock takes the lock file next to the journal, so concurrent runs read and
+// write the journal one at a time. A lock file is used rather than flock so
+// it behaves the same on every platform.
+func lock(path string) (string, error) {
+	name := path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(lockWait)
+	for {
+		// #nosec G304 - the lock lives in the user's state directory
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, _ = f.WriteString(strconv.Itoa(os.Getpid()))
+			return name, f.Close()
+		}
+		if !errors.Is(err, iofs.ErrExist) {
+			return "", err
+		}
+
+		if info, err := os.Stat(name); err == nil && time.Since(info.ModTime()) > lockStale {
+			_ = os.Remove(name)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("journal is locked by another run, remove %s if none is running", name)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// Close releases the journal lock. It is safe to call more than once.
+func (j *Journal) Close() error {
+	if j.lock == "" {
+		return nil
+	}
+	err := os.Remove(j.lock)
+	j.lock = ""
+	if errors.Is(err, iofs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
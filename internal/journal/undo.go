@@ -0,0 +1,150 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package journal
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"strings"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+)
+
+// Undo outcomes of a single record
+const (
+	Removed  = "removed"
+	Missing  = "missing"
+	Kept     = "kept"
+	Modified = "modified"
+)
+
+// UndoResult is what undo did to a recorded path
+type UndoResult struct {
+	Record Record
+	Action string
+	Reason string
+}
+
+// ModifiedError lists the files that changed since a run created them
+type ModifiedError struct {
+	Paths []string
+}
+
+func (e *ModifiedError) Error() string {
+	return fmt.Sprintf("refusing to undo, modified since creation (use --force to remove anyway):\n  %s",
+		strings.Join(e.Paths, "\n  "))
+}
+
+// Undo removes what a run created, newest first. When any created file was
+// modified since, nothing is removed unless force is set. Directories are
+// only removed once empty; paths that had to be kept stay in the run so a
+// later undo can retry them.
+func Undo(run *Run, force bool) ([]UndoResult, error) {
+	if run.Undone {
+		return nil, fmt.Errorf("run %d was already undone", run.ID)
+	}
+
+	if !force {
+		var modified []string
+		for _, record := range run.Records {
+			if changed, _ := isModified(record); changed {
+				modified = append(modified, record.Path)
+			}
+		}
+		if len(modified) > 0 {
+			return nil, &ModifiedError{Paths: modified}
+		}
+	}
+
+	var (
+		results []UndoResult
+		kept    []Record
+	)
+	for i := len(run.Records) - 1; i >= 0; i-- {
+		record := run.Records[i]
+		result := UndoResult{Record: record, Action: Removed}
+
+		if _, err := os.Lstat(record.Path); errors.Is(err, iofs.ErrNotExist) {
+			result.Action = Missing
+		} else if changed, reason := isModified(record); changed && !force {
+			result.Action, result.Reason = Modified, reason
+		} else if err := os.Remove(record.Path); err != nil {
+			result.Action, result.Reason = Kept, err.Error()
+			if record.Type == create.TypeDir {
+				result.Reason = "directory is not empty"
+			}
+		}
+		if result.Action == Kept || result.Action == Modified {
+			kept = append([]Record{record}, kept...)
+		}
+		results = append(results, result)
+	}
+
+	run.Records = kept
+	run.Undone = len(kept) == 0
+	return results, nil
+}
+
+// isModified reports whether a recorded path changed since it was created
+func isModified(record Record) (bool, string) {
+	info, err := os.Lstat(record.Path)
+	if err != nil {
+		return false, ""
+	}
+
+	switch record.Type {
+	case create.TypeFile:
+		if !info.Mode().IsRegular() {
+			return true, "no longer a regular file"
+		}
+		hash, err := HashFile(record.Path)
+		if err != nil {
+			return true, err.Error()
+		}
+		if hash != record.Hash {
+			return true, "content changed"
+		}
+	case create.TypeSymlink:
+		target, err := os.Readlink(record.Path)
+		if err != nil || target != record.Target {
+			return true, "symlink target changed"
+		}
+	case create.TypeHardlink:
+		// Removing the link is only safe while its target still holds the data
+		target, err := os.Stat(record.Target)
+		if err != nil || !os.SameFile(info, target) {
+			return true, "hardlink target changed"
+		}
+	case create.TypeDir:
+		if !info.IsDir() {
+			return true, "no longer a directory"
+		}
+	}
+	return false, ""
+}
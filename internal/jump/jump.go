@@ -0,0 +1,258 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package jump
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+const (
+	// MaxRank is the total rank above which every rank is aged, so old
+	// directories fade out of the database
+	MaxRank = 10000
+	// CreatedBoost is the rank a directory created by burrow starts with
+	CreatedBoost = 5
+)
+
+// Dir is a visited directory
+type Dir struct {
+	Path string    `json:"path"`
+	Rank float64   `json:"rank"` // how often it was visited, aged over time
+	Last time.Time `json:"last"` // last visit
+}
+
+// Score ranks the directory by frecency: its rank weighted by how
+// recently it was visited
+func (d Dir) Score(now time.Time) float64 {
+	switch age := now.Sub(d.Last); {
+	case age < time.Hour:
+		return d.Rank * 4
+	case age < 24*time.Hour:
+		return d.Rank * 2
+	case age < 7*24*time.Hour:
+		return d.Rank / 2
+	default:
+		return d.Rank / 4
+	}
+}
+
+// DB is the database of visited directories, stored as JSON in the user's
+// state directory
+type DB struct {
+	path  string
+	index map[string]int // position of each path in Dirs, nil until needed
+	Dirs  []Dir          `json:"dirs"`
+}
+
+// Path returns the location of the database file
+func Path() (string, error) {
+	dir, err := pt.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jump.json"), nil
+}
+
+// Open reads the database, returning an empty one if none exists yet
+func Open() (*DB, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	db := &DB{path: path}
+	// #nosec G304 - the database lives in the user's state directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return db, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, db); err != nil {
+		return nil, fmt.Errorf("corrupt jump database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Save writes the database back, aging the ranks once they grow too large
+func (db *DB) Save() error {
+	db.age()
+	data, err := json.MarshalIndent(db, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(db.path), 0700); err != nil {
+		return err
+	}
+	// Several shells may save at once, each writes its own file and renames it
+	tmp, err := os.CreateTemp(filepath.Dir(db.path), "jump-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), db.path)
+}
+
+// Visit records a visit to dir and reports whether the database changed.
+// Staying in the directory of the last visit is not counted again.
+func (db *DB) Visit(dir string) bool {
+	if latest := db.latest(); latest != nil && latest.Path == dir {
+		return false
+	}
+	db.add(dir, 1)
+	return true
+}
+
+// Boost gives a directory created by burrow an initial rank
+func (db *DB) Boost(dir string) {
+	db.add(dir, CreatedBoost)
+}
+
+func (db *DB) add(dir string, rank float64) {
+	if db.index == nil {
+		db.index = make(map[string]int, len(db.Dirs))
+		for i, d := range db.Dirs {
+			db.index[d.Path] = i
+		}
+	}
+
+	now := time.Now()
+	if i, ok := db.index[dir]; ok {
+		db.Dirs[i].Rank += rank
+		db.Dirs[i].Last = now
+		return
+	}
+	db.index[dir] = len(db.Dirs)
+	db.Dirs = append(db.Dirs, Dir{Path: dir, Rank: rank, Last: now})
+}
+
+func (db *DB) latest() *Dir {
+	var latest *Dir
+	for i := range db.Dirs {
+		if latest == nil || db.Dirs[i].Last.After(latest.Last) {
+			latest = &db.Dirs[i]
+		}
+	}
+	return latest
+}
+
+// Remove deletes dir from the database and reports whether it was there
+func (db *DB) Remove(dir string) bool {
+	for i := range db.Dirs {
+		if db.Dirs[i].Path == dir {
+			db.Dirs = append(db.Dirs[:i], db.Dirs[i+1:]...)
+			db.index = nil
+			return true
+		}
+	}
+	return false
+}
+
+// age scales every rank down once their total exceeds MaxRank, dropping
+// the directories that fall below a single visit
+func (db *DB) age() {
+	total := 0.0
+	for _, d := range db.Dirs {
+		total += d.Rank
+	}
+	if total <= MaxRank {
+		return
+	}
+	kept := db.Dirs[:0]
+	for _, d := range db.Dirs {
+		d.Rank *= 0.9
+		if d.Rank >= 1 {
+			kept = append(kept, d)
+		}
+	}
+	db.Dirs = kept
+	db.index = nil
+}
+
+// Query returns the directories matching every fragment, best first.
+// Fragments match case-insensitively and in order, and the last one must
+// match the last path component. Directories that no longer exist are
+// dropped from the database.
+func (db *DB) Query(fragments ...string) []Dir {
+	now := time.Now()
+	var matches []Dir
+	kept := db.Dirs[:0]
+	for _, d := range db.Dirs {
+		if info, err := os.Stat(d.Path); err != nil || !info.IsDir() {
+			continue
+		}
+		kept = append(kept, d)
+		if matchFragments(d.Path, fragments) {
+			matches = append(matches, d)
+		}
+	}
+	db.Dirs = kept
+	db.index = nil
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score(now) > matches[j].Score(now)
+	})
+	return matches
+}
+
+func matchFragments(path string, fragments []string) bool {
+	if len(fragments) == 0 {
+		return true
+	}
+	lower := strings.ToLower(path)
+	last := strings.ToLower(fragments[len(fragments)-1])
+	if !strings.Contains(strings.ToLower(filepath.Base(path)), last) {
+		return false
+	}
+	for _, fragment := range fragments {
+		fragment = strings.ToLower(fragment)
+		i := strings.Index(lower, fragment)
+		if i < 0 {
+			return false
+		}
+		lower = lower[i+len(fragment):]
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package license
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/elaurentium/burrow/templates"
+)
+
+// Data fills in the license texts and documents
+type Data struct {
+	Holder  string
+	Year    int
+	Project string
+	Contact string
+}
+
+// aliases maps SPDX identifiers to the text they share in the corpus
+var aliases = map[string]string{
+	"GPL-3.0-only":     "GPL-3.0",
+	"GPL-3.0-or-later": "GPL-3.0",
+}
+
+// IDs returns the SPDX identifiers of the embedded license texts
+func IDs() []string {
+	files, _ := iofs.ReadDir(templates.Licenses, "licenses")
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		ids = append(ids, strings.TrimSuffix(file.Name(), ".txt"))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Text returns the license text for id, matched case-insensitively
+func Text(id string, data Data) (string, error) {
+	name := id
+	if alias, ok := aliases[id]; ok {
+		name = alias
+	}
+	for _, known := range IDs() {
+		if strings.EqualFold(known, name) {
+			return render(templates.Licenses, path.Join("licenses", known+".txt"), data)
+		}
+	}
+	return "", fmt.Errorf("unknown license %q, known licenses: %s", id, strings.Join(IDs(), ", "))
+}
+
+// Document is a well-known top-level document burrow can generate
+type Document struct {
+	Name string // name given to "b doc"
+	File string // file it is written to by default
+	src  string
+}
+
+// Documents are the documents burrow can generate
+var Documents = []Document{
+	{Name: "contributing", File: "CONTRIBUTING.md", src: "docs/CONTRIBUTING.md"},
+	{Name: "code-of-conduct", File: "CODE_OF_CONDUCT.md", src: "docs/CODE_OF_CONDUCT.md"},
+}
+
+// FindDocument returns the document called name
+func FindDocument(name string) (Document, error) {
+	names := make([]string, 0, len(Documents))
+	for _, doc := range Documents {
+		if strings.EqualFold(doc.Name, name) {
+			return doc, nil
+		}
+		names = append(names, doc.Name)
+	}
+	return Document{}, fmt.Errorf("unknown document %q, known documents: %s", name, strings.Join(names, ", "))
+}
+
+// Text renders the document
+func (d Document) Text(data Data) (string, error) {
+	return render(templates.Docs, d.src, data)
+}
+
+func render(fsys iofs.FS, name string, data Data) (string, error) {
+	text, err := iofs.ReadFile(fsys, name)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(name).Parse(string(text))
+	if err != nil {
+		return "", err
+	}
+	if data.Holder == "" && strings.Contains(string(text), ".Holder") {
+		return "", ErrNoHolder
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ErrNoHolder is returned when a text names a copyright holder and none was
+// given
+var ErrNoHolder = errors.New("no copyright holder: use --holder, set license.holder in the config or git config user.name")
+
+// ErrExists is returned by Write when the file exists and force is not set
+var ErrExists = errors.New("file already exists, use --force to overwrite it")
+
+// Write writes text to path. An existing file is only replaced with force.
+func Write(path, text string, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	// #nosec G302 G304 - documents are regular, world-readable project files
+	f, err := os.OpenFile(path, flags, 0644)
+	if errors.Is(err, iofs.ErrExist) {
+		return fmt.Errorf("%s: %w", path, ErrExists)
+	} else if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(text); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
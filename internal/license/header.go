@@ -0,0 +1,175 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package license
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+)
+
+// Notice is what a license header says about a file
+type Notice struct {
+	ID     string // SPDX license identifier or expression
+	Holder string
+	Year   int
+}
+
+// Lines returns the uncommented lines of the header
+func (n Notice) Lines() []string {
+	lines := []string{"SPDX-License-Identifier: " + n.ID}
+	if n.Holder != "" {
+		lines = append([]string{fmt.Sprintf("Copyright (c) %d %s", n.Year, n.Holder)}, lines...)
+	}
+	return lines
+}
+
+// comment is the comment syntax of a language: a prefix for every line,
+// and for block comments the lines opening and closing the block
+type comment struct {
+	open, prefix, close string
+}
+
+var (
+	slashes = comment{prefix: "// "}
+	hashes  = comment{prefix: "# "}
+	dashes  = comment{prefix: "-- "}
+	semis   = comment{prefix: ";; "}
+	percent = comment{prefix: "% "}
+	cblock  = comment{open: "/*", prefix: " * ", close: " */"}
+	xml     = comment{open: "<!--", prefix: "  ", close: "-->"}
+)
+
+// comments maps extensions, and a few well-known file names, to their
+// comment syntax. Files not listed here are not source files.
+var comments = map[string]comment{
+	".go": slashes, ".c": slashes, ".h": slashes, ".cc": slashes, ".cpp": slashes,
+	".hpp": slashes, ".cs": slashes, ".java": slashes, ".kt": slashes, ".kts": slashes,
+	".scala": slashes, ".swift": slashes, ".rs": slashes, ".js": slashes, ".mjs": slashes,
+	".cjs": slashes, ".jsx": slashes, ".ts": slashes, ".tsx": slashes, ".dart": slashes,
+	".php": slashes, ".proto": slashes, ".zig": slashes, ".v": slashes,
+
+	".sh": hashes, ".bash": hashes, ".zsh": hashes, ".fish": hashes, ".ps1": hashes,
+	".nu": hashes, ".py": hashes, ".rb": hashes, ".pl": hashes, ".r": hashes,
+	".ex": hashes, ".exs": hashes, ".nim": hashes, ".jl": hashes, ".tf": hashes,
+	".yaml": hashes, ".yml": hashes, ".toml": hashes, ".cmake": hashes, ".mk": hashes,
+
+	".sql": dashes, ".lua": dashes, ".hs": dashes, ".elm": dashes,
+	".el": semis, ".clj": semis, ".scm": semis, ".lisp": semis,
+	".erl": percent, ".tex": percent,
+	".css": cblock, ".scss": cblock, ".less": cblock,
+	".html": xml, ".htm": xml, ".xml": xml, ".svg": xml, ".vue": xml,
+
+	"makefile": hashes, "dockerfile": hashes, "containerfile": hashes,
+	"cmakelists.txt": hashes, "rakefile": hashes, "gemfile": hashes,
+}
+
+func commentFor(path string) (comment, bool) {
+	base := strings.ToLower(filepath.Base(path))
+	if c, ok := comments[base]; ok {
+		return c, true
+	}
+	c, ok := comments[strings.ToLower(filepath.Ext(base))]
+	return c, ok
+}
+
+// Header returns the notice commented for the language of path, and false
+// when path is not a source file burrow knows the comment syntax of
+func Header(n Notice, path string) (string, bool) {
+	c, ok := commentFor(path)
+	if !ok {
+		return "", false
+	}
+
+	var buf strings.Builder
+	if c.open != "" {
+		buf.WriteString(c.open + "\n")
+	}
+	for _, line := range n.Lines() {
+		buf.WriteString(c.prefix + line + "\n")
+	}
+	if c.close != "" {
+		buf.WriteString(c.close + "\n")
+	}
+	return buf.String(), true
+}
+
+// Apply prepends the header to every file entry that is a source file. A
+// shebang, an XML declaration or a doctype stays on the first line.
+func Apply(entries []create.Entry, n Notice) {
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Type != create.TypeFile {
+			continue
+		}
+		header, ok := Header(n, entry.Path)
+		if !ok {
+			continue
+		}
+
+		body := string(entry.Content)
+		first := ""
+		if hasPrologue(body) {
+			first, body, _ = strings.Cut(body, "\n")
+			first += "\n"
+		}
+		if body != "" {
+			header += "\n"
+		}
+		entry.Content = []byte(first + header + body)
+	}
+}
+
+// Applies reports whether Apply would add a header to any of the entries
+func Applies(entries []create.Entry) bool {
+	for _, entry := range entries {
+		if _, ok := commentFor(entry.Path); ok && entry.Type == create.TypeFile {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrologue(body string) bool {
+	for _, prefix := range []string{"#!", "<?xml", "<!DOCTYPE", "<!doctype"} {
+		if strings.HasPrefix(body, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9.+()-]+( (AND|OR|WITH) [A-Za-z0-9.+()-]+)*$`)
+
+// ValidID reports whether id looks like an SPDX license identifier or
+// expression, such as "MIT" or "Apache-2.0 OR MIT"
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
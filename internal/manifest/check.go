@@ -0,0 +1,196 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package manifest
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+// Kinds of drift reported by Check
+const (
+	Missing  = "missing"
+	Extra    = "extra"
+	Mismatch = "type"
+	ModeDiff = "mode"
+	Target   = "target"
+)
+
+// Issue is a single difference between a manifest and the working tree
+type Issue struct {
+	Kind     string `json:"kind"`
+	Path     string `json:"path"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// Report is the result of checking a tree against a manifest
+type Report struct {
+	Root   string  `json:"root"`
+	OK     bool    `json:"ok"`
+	Issues []Issue `json:"issues"`
+}
+
+// CheckOptions controls which paths Check considers
+type CheckOptions struct {
+	GitIgnore bool     // do not report extra paths ignored by .gitignore files
+	Skip      []string // paths never reported as extra, such as the manifest itself
+}
+
+// Check compares the tree below root with the manifest. Every declared path
+// must exist with the declared type, mode and target, and declared
+// directories, the root included, must not contain undeclared paths.
+func Check(m *Manifest, root string, opts CheckOptions) (*Report, error) {
+	report := &Report{Root: root, Issues: []Issue{}}
+
+	declared := make(map[string]bool)
+	for _, skip := range opts.Skip {
+		declared[path.Clean(filepath.ToSlash(skip))] = true
+	}
+	// The root is implicitly declared
+	dirs := []string{"."}
+	for _, e := range m.Entries {
+		p := path.Clean(filepath.ToSlash(e.Path))
+		declared[p] = true
+		// Parents of declared paths are implicitly declared
+		for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+			declared[dir] = true
+		}
+		st, err := create.FileStat(filepath.Join(root, filepath.FromSlash(p)))
+		if os.IsNotExist(err) {
+			report.add(Issue{Kind: Missing, Path: p, Expected: string(e.EntryType())})
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if e.EntryType() == create.TypeHardlink {
+			if issue, ok := checkHardlink(root, p, e); !ok {
+				report.add(issue)
+			}
+			continue
+		}
+		if st.Type() != e.EntryType() {
+			report.add(Issue{Kind: Mismatch, Path: p, Expected: string(e.EntryType()), Actual: typeName(st)})
+			continue
+		}
+		if st.Type() == create.TypeDir {
+			dirs = append(dirs, p)
+		}
+		if e.Mode != 0 && st.Type() != create.TypeSymlink && ModeOf(st.Perm()) != e.Mode {
+			report.add(Issue{Kind: ModeDiff, Path: p, Expected: e.Mode.String(), Actual: ModeOf(st.Perm()).String()})
+		}
+		if st.Type() == create.TypeSymlink {
+			target, err := os.Readlink(filepath.Join(root, filepath.FromSlash(p)))
+			if err != nil {
+				return nil, err
+			}
+			if target != e.Target {
+				report.add(Issue{Kind: Target, Path: p, Expected: e.Target, Actual: target})
+			}
+		}
+	}
+
+	var ignore *pt.GitIgnore
+	if opts.GitIgnore {
+		ignore = pt.NewGitIgnore(root)
+	}
+	for _, dir := range dirs {
+		extras, err := extraPaths(root, dir, declared, ignore)
+		if err != nil {
+			return nil, err
+		}
+		for _, extra := range extras {
+			report.add(Issue{Kind: Extra, Path: extra})
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Path < report.Issues[j].Path
+	})
+	report.OK = len(report.Issues) == 0
+	return report, nil
+}
+
+func (r *Report) add(issue Issue) {
+	r.Issues = append(r.Issues, issue)
+}
+
+// extraPaths lists the children of a declared directory that the manifest
+// does not mention. Undeclared subdirectories are reported once, not walked.
+func extraPaths(root, dir string, declared map[string]bool, ignore *pt.GitIgnore) ([]string, error) {
+	children, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var extras []string
+	for _, child := range children {
+		p := path.Join(dir, child.Name())
+		if declared[p] {
+			continue
+		}
+		if ignore != nil {
+			// Pick up nested .gitignore files on the way down
+			for d := dir; d != "."; d = path.Dir(d) {
+				ignore.Load(d)
+			}
+			if ignore.Ignored(p, child.IsDir()) {
+				continue
+			}
+		}
+		extras = append(extras, p)
+	}
+	return extras, nil
+}
+
+func typeName(st create.Stat) string {
+	if t := st.Type(); t != "" {
+		return string(t)
+	}
+	return "special"
+}
+
+// checkHardlink reports whether the path is the same file as its target
+func checkHardlink(root, p string, e Entry) (Issue, bool) {
+	link, err := os.Stat(filepath.Join(root, filepath.FromSlash(p)))
+	if err != nil || !link.Mode().IsRegular() {
+		return Issue{Kind: Mismatch, Path: p, Expected: string(create.TypeHardlink), Actual: "not a regular file"}, false
+	}
+	target, err := os.Stat(filepath.Join(root, filepath.FromSlash(e.Target)))
+	if err != nil || !os.SameFile(link, target) {
+		return Issue{Kind: Target, Path: p, Expected: e.Target, Actual: "a different file"}, false
+	}
+	return Issue{}, true
+}
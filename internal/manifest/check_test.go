@@ -0,0 +1,94 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"src", "docs"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"README.md", "stray.txt", "src/main.go", "src/extra.go", "docs/notes.md", "burrow.yaml"} {
+		if err := os.WriteFile(filepath.Join(root, file), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := mustParse(t, `entries:
+  - path: README.md
+  - path: src/
+  - path: src/main.go
+  - path: docs/notes.md
+  - path: missing.txt
+  - path: docs
+    type: file
+`)
+	report, err := Check(m, root, CheckOptions{Skip: []string{"burrow.yaml"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Issue
+	for _, issue := range report.Issues {
+		got = append(got, Issue{Kind: issue.Kind, Path: issue.Path})
+	}
+	want := []Issue{
+		{Kind: Mismatch, Path: "docs"},
+		{Kind: Missing, Path: "missing.txt"},
+		{Kind: Extra, Path: "src/extra.go"},
+		{Kind: Extra, Path: "stray.txt"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if report.OK {
+		t.Error("report is OK despite issues")
+	}
+}
+
+func TestCheckMatches(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	m := mustParse(t, "entries:\n  - path: a/b/\n")
+	report, err := Check(m, root, CheckOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK {
+		t.Errorf("got issues %+v, want none", report.Issues)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package manifest
+
+import (
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+// ExportOptions controls what Export records about a directory
+type ExportOptions struct {
+	Content   bool     // include the content of text files
+	MaxSize   int64    // largest file whose content is included
+	Modes     bool     // record permission modes
+	Symlinks  bool     // record symlinks, otherwise they are skipped
+	Include   []string // only export paths matching these globs
+	Exclude   []string // skip paths matching these globs
+	GitIgnore bool     // skip paths ignored by .gitignore files
+}
+
+// Export walks dir and describes its structure as a manifest
+func Export(dir string, opts ExportOptions) (*Manifest, error) {
+	var ignore *pt.GitIgnore
+	if opts.GitIgnore {
+		ignore = pt.NewGitIgnore(dir)
+	}
+
+	var entries []Entry
+	err := filepath.WalkDir(dir, func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if (ignore != nil && ignore.Ignored(rel, d.IsDir())) || pt.MatchAny(opts.Exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		st, err := create.FileStat(p)
+		if err != nil {
+			return err
+		}
+
+		entry := Entry{Path: rel, Type: string(st.Type())}
+		if opts.Modes && st.Type() != create.TypeSymlink {
+			entry.Mode = ModeOf(st.Perm())
+		}
+
+		switch st.Type() {
+		case create.TypeDir:
+			if ignore != nil {
+				ignore.Load(rel)
+			}
+		case create.TypeSymlink:
+			if !opts.Symlinks {
+				return nil
+			}
+			if entry.Target, err = os.Readlink(p); err != nil {
+				return err
+			}
+		case create.TypeFile:
+			if opts.Content && st.Size > 0 && st.Size <= opts.MaxSize {
+				if entry.Content, err = readText(p); err != nil {
+					return err
+				}
+			}
+		default:
+			// Devices, pipes and sockets cannot be recreated by burrow
+			return nil
+		}
+
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Include) > 0 {
+		entries = filterIncluded(entries, opts.Include)
+	}
+
+	return &Manifest{Version: 1, Entries: entries}, nil
+}
+
+// filterIncluded keeps the entries matching an include glob, along with the
+// directories leading to them.
+func filterIncluded(entries []Entry, include []string) []Entry {
+	keep := make(map[string]bool)
+	for _, e := range entries {
+		if !pt.MatchAny(include, e.Path) {
+			continue
+		}
+		keep[e.Path] = true
+		for dir := filepath.ToSlash(filepath.Dir(e.Path)); dir != "."; dir = filepath.ToSlash(filepath.Dir(dir)) {
+			keep[dir] = true
+		}
+	}
+
+	var out []Entry
+	for _, e := range entries {
+		if keep[e.Path] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// readText returns the content of a text file, or nothing for binary files
+func readText(path string) (string, error) {
+	// #nosec G304 - path comes from walking the exported directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(data) || strings.ContainsRune(string(data), 0) {
+		fmt.Fprintf(os.Stderr, "skipping content of binary file %s\n", path)
+		return "", nil
+	}
+	return string(data), nil
+}
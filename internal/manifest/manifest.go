@@ -0,0 +1,348 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported manifest formats
+const (
+	YAML = "yaml"
+	JSON = "json"
+)
+
+// Manifest is a declarative description of a directory structure
+type Manifest struct {
+	Version int     `yaml:"version,omitempty" json:"version,omitempty"`
+	Root    string  `yaml:"root,omitempty" json:"root,omitempty"`
+	Entries []Entry `yaml:"entries" json:"entries"`
+}
+
+// Entry describes a directory, file, symlink or hardlink in a manifest. A
+// symlink target is stored as is, a hardlink target is a path in the
+// manifest, relative to the root.
+type Entry struct {
+	Path    string `yaml:"path" json:"path"`
+	Type    string `yaml:"type,omitempty" json:"type,omitempty"`
+	Content string `yaml:"content,omitempty" json:"content,omitempty"`
+	Source  string `yaml:"source,omitempty" json:"source,omitempty"`
+	Mode    Mode   `yaml:"mode,omitempty" json:"mode,omitempty"`
+	Target  string `yaml:"target,omitempty" json:"target,omitempty"`
+}
+
+// FormatOf returns the manifest format implied by a file name
+func FormatOf(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return JSON
+	}
+	return YAML
+}
+
+// Load reads and validates the manifest at path
+func Load(path string) (*Manifest, error) {
+	// #nosec G304 - the manifest path is chosen by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := Parse(data, FormatOf(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse decodes and validates a manifest in the given format
+func Parse(data []byte, format string) (*Manifest, error) {
+	var m Manifest
+	var err error
+	switch format {
+	case JSON:
+		err = json.Unmarshal(data, &m)
+	case YAML:
+		err = yaml.Unmarshal(data, &m)
+	default:
+		return nil, fmt.Errorf("unsupported manifest format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Marshal encodes the manifest in the given format
+func (m *Manifest) Marshal(format string) ([]byte, error) {
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case YAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(m); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), enc.Close()
+	default:
+		return nil, fmt.Errorf("unsupported manifest format: %s", format)
+	}
+}
+
+// Validate checks that every entry is well formed and stays inside the
+// root. No entry may sit below a symlink declared in the manifest, as it
+// would be created wherever the link points.
+func (m *Manifest) Validate() error {
+	if m.Root != "" {
+		if err := validatePath(m.Root); err != nil {
+			return fmt.Errorf("root: %w", err)
+		}
+	}
+
+	links := make(map[string]bool)
+	for _, e := range m.Entries {
+		if e.EntryType() == create.TypeSymlink {
+			links[path.Clean(filepath.ToSlash(e.Path))] = true
+		}
+	}
+
+	for i, e := range m.Entries {
+		if strings.TrimSpace(e.Path) == "" {
+			return fmt.Errorf("entry %d: path is required", i)
+		}
+		if err := validatePath(e.Path); err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+		if link := linkAbove(links, e.Path); link != "" {
+			return fmt.Errorf("entry %d (%s): parent %s is a symlink in the manifest", i, e.Path, link)
+		}
+
+		switch e.EntryType() {
+		case create.TypeDir:
+			if e.Content != "" || e.Source != "" {
+				return fmt.Errorf("entry %d (%s): directories cannot have content", i, e.Path)
+			}
+		case create.TypeFile:
+			if e.Content != "" && e.Source != "" {
+				return fmt.Errorf("entry %d (%s): content and source are mutually exclusive", i, e.Path)
+			}
+		case create.TypeSymlink:
+			if e.Target == "" {
+				return fmt.Errorf("entry %d (%s): symlink requires a target", i, e.Path)
+			}
+		case create.TypeHardlink:
+			if e.Target == "" {
+				return fmt.Errorf("entry %d (%s): hardlink requires a target", i, e.Path)
+			}
+			if err := validatePath(e.Target); err != nil {
+				return fmt.Errorf("entry %d (%s): target: %w", i, e.Path, err)
+			}
+			if link := linkAbove(links, e.Target); link != "" {
+				return fmt.Errorf("entry %d (%s): target parent %s is a symlink in the manifest", i, e.Path, link)
+			}
+		default:
+			return fmt.Errorf("entry %d (%s): unknown type %q", i, e.Path, e.Type)
+		}
+	}
+	return nil
+}
+
+// EntryType returns the declared type, inferring it when omitted
+func (e Entry) EntryType() create.EntryType {
+	switch {
+	case e.Type != "":
+		return create.EntryType(e.Type)
+	case e.Target != "":
+		return create.TypeSymlink
+	case e.Content != "" || e.Source != "":
+		return create.TypeFile
+	default:
+		return create.EntryFor(e.Path).Type
+	}
+}
+
+// Resolve converts the manifest into creator entries below root. Content
+// sources are read relative to baseDir, the directory of the manifest, and
+// must stay inside it, symlinks included, unless allowOutside is set.
+// Entries whose parent is an existing symlink out of root are rejected.
+func (m *Manifest) Resolve(root, baseDir string, allowOutside bool) ([]create.Entry, error) {
+	var sources *os.Root
+	if !allowOutside && m.hasSources() {
+		var err error
+		if sources, err = os.OpenRoot(baseDir); err != nil {
+			return nil, err
+		}
+		defer sources.Close()
+	}
+
+	entries := make([]create.Entry, 0, len(m.Entries))
+	for _, e := range m.Entries {
+		if err := inside(root, e.Path); err != nil {
+			return nil, err
+		}
+		if e.EntryType() == create.TypeHardlink {
+			if err := inside(root, e.Target); err != nil {
+				return nil, err
+			}
+		}
+
+		entry := create.Entry{
+			Path:   filepath.Join(root, filepath.FromSlash(e.Path)),
+			Type:   e.EntryType(),
+			Mode:   e.Mode.FileMode(),
+			Target: e.Target,
+		}
+		if entry.Type == create.TypeHardlink {
+			entry.Target = filepath.Join(root, filepath.FromSlash(e.Target))
+		}
+
+		switch {
+		case e.Content != "":
+			entry.Content = []byte(e.Content)
+		case e.Source != "":
+			data, err := readSource(sources, baseDir, e.Source)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to read source: %w", e.Path, err)
+			}
+			entry.Content = data
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (m *Manifest) hasSources() bool {
+	for _, e := range m.Entries {
+		if e.Source != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// readSource reads a content source through root, which keeps it and any
+// symlink on the way inside the manifest directory. Without a root the
+// source is read from anywhere.
+func readSource(root *os.Root, baseDir, source string) ([]byte, error) {
+	if root == nil {
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(baseDir, source)
+		}
+		// #nosec G304 - reading outside sources was allowed by the user
+		return os.ReadFile(source)
+	}
+	if err := validatePath(source); err != nil {
+		return nil, err
+	}
+	f, err := root.Open(filepath.FromSlash(source))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// linkAbove returns the symlink among links that p sits below, or ""
+func linkAbove(links map[string]bool, p string) string {
+	for dir := path.Dir(path.Clean(filepath.ToSlash(p))); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if links[dir] {
+			return dir
+		}
+	}
+	return ""
+}
+
+// inside checks that the existing parents of rel below root do not lead
+// out of root through a symlink
+func inside(root, rel string) error {
+	if root == "" {
+		root = "."
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	dir := root
+	for _, part := range strings.Split(path.Dir(path.Clean(filepath.ToSlash(rel))), "/") {
+		if part == "." {
+			break
+		}
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if errors.Is(err, iofs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+		if r, err := filepath.Rel(realRoot, resolved); err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("%s: parent %s is a symlink out of %s", rel, dir, root)
+		}
+	}
+	return nil
+}
+
+func validatePath(path string) error {
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path %s must be relative", path)
+	}
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if part == ".." {
+			return fmt.Errorf("path %s escapes the manifest root", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"valid", "entries:\n  - path: a/b.go\n  - path: link\n    target: a\n", ""},
+		{"absolute path", "entries:\n  - path: /etc/x\n", "must be relative"},
+		{"escaping path", "entries:\n  - path: a/../../x\n", "escapes"},
+		{"absolute root", "root: /etc\nentries:\n  - path: x\n", "root"},
+		{"escaping root", "root: ../up\nentries:\n  - path: x\n", "root"},
+		{"below a declared symlink", "entries:\n  - path: out\n    target: /tmp/outside\n  - path: out/pwned.txt\n", "symlink"},
+		{"below a later symlink", "entries:\n  - path: out/pwned.txt\n  - path: out\n    target: /tmp/outside\n", "symlink"},
+		{"hardlink through a symlink", "entries:\n  - path: out\n    target: /etc\n  - path: h\n    type: hardlink\n    target: out/passwd\n", "symlink"},
+		{"content and source", "entries:\n  - path: a.txt\n    content: x\n    source: y\n", "mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data), YAML)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("got %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, data string) *Manifest {
+	t.Helper()
+	m, err := Parse([]byte(data), YAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestResolveSources(t *testing.T) {
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	base := t.TempDir()
+	if err := os.WriteFile(filepath.Join(base, "readme.md"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret"), filepath.Join(base, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("readme.md", filepath.Join(base, "alias")); err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+
+	tests := []struct {
+		source       string
+		allowOutside bool
+		want         string // "" expects an error
+	}{
+		{"readme.md", false, "hello"},
+		{"alias", false, "hello"},
+		{"escape", false, ""},
+		{"../" + filepath.Base(outside) + "/secret", false, ""},
+		{filepath.Join(outside, "secret"), false, ""},
+		{"escape", true, "secret"},
+		{filepath.Join(outside, "secret"), true, "secret"},
+	}
+	for _, tt := range tests {
+		m := &Manifest{Entries: []Entry{{Path: "out.txt", Source: tt.source}}}
+		entries, err := m.Resolve(root, base, tt.allowOutside)
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("source %s: read %q, want an error", tt.source, entries[0].Content)
+		case tt.want != "" && err != nil:
+			t.Errorf("source %s: %v", tt.source, err)
+		case tt.want != "" && string(entries[0].Content) != tt.want:
+			t.Errorf("source %s: got %q, want %q", tt.source, entries[0].Content, tt.want)
+		}
+	}
+}
+
+func TestResolveExistingSymlinks(t *testing.T) {
+	outside := t.TempDir()
+	root := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(root, "out")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, "real"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("real", filepath.Join(root, "in")); err != nil {
+		t.Fatal(err)
+	}
+
+	m := mustParse(t, "entries:\n  - path: in/a/ok.txt\n  - path: out\n")
+	if _, err := m.Resolve(root, root, false); err != nil {
+		t.Fatalf("symlink inside the root: %v", err)
+	}
+	m = mustParse(t, "entries:\n  - path: out/pwned.txt\n")
+	if _, err := m.Resolve(root, root, false); err == nil {
+		t.Fatal("entry below a symlink out of the root: want an error")
+	}
+	m = mustParse(t, "entries:\n  - path: h\n    type: hardlink\n    target: out/file\n")
+	if _, err := m.Resolve(root, root, false); err == nil {
+		t.Fatal("hardlink target below a symlink out of the root: want an error")
+	}
+}
@@ -0,0 +1,114 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mode is a permission mode written in octal, such as "0644"
+type Mode uint32
+
+// ParseMode parses an octal permission string
+func ParseMode(s string) (Mode, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "0o")
+	v, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || v > 0o7777 {
+		return 0, fmt.Errorf("invalid mode %q: expected octal permissions such as 0644", s)
+	}
+	return Mode(v), nil
+}
+
+// FileMode returns the mode as an os.FileMode, mapping the setuid, setgid
+// and sticky bits to their os.FileMode flags
+func (m Mode) FileMode() os.FileMode {
+	mode := os.FileMode(m & 0o777)
+	if m&0o4000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if m&0o2000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if m&0o1000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
+// ModeOf converts the permission and special bits of an os.FileMode back
+// to an octal Mode
+func ModeOf(mode os.FileMode) Mode {
+	m := Mode(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		m |= 0o4000
+	}
+	if mode&os.ModeSetgid != 0 {
+		m |= 0o2000
+	}
+	if mode&os.ModeSticky != 0 {
+		m |= 0o1000
+	}
+	return m
+}
+
+func (m Mode) String() string {
+	return fmt.Sprintf("%04o", uint32(m))
+}
+
+func (m Mode) MarshalYAML() (interface{}, error) {
+	return m.String(), nil
+}
+
+// UnmarshalYAML reads the literal text of the node, so both 0644 and "0644"
+// are understood as octal.
+func (m *Mode) UnmarshalYAML(value *yaml.Node) error {
+	mode, err := ParseMode(value.Value)
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+func (m Mode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+func (m *Mode) UnmarshalJSON(data []byte) error {
+	mode, err := ParseMode(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
@@ -0,0 +1,291 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package paths
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxExpansions bounds how many paths a single argument may expand to
+const MaxExpansions = 100000
+
+var (
+	numericRange = regexp.MustCompile(`^(-?\d+)\.\.(-?\d+)(?:\.\.(-?\d+))?$`)
+	letterRange  = regexp.MustCompile(`^([a-zA-Z])\.\.([a-zA-Z])(?:\.\.(-?\d+))?$`)
+)
+
+// Expand performs shell-like brace expansion on a path, so that
+// "src/{api,web}/main.go" and "day{01..31}.md" behave the same no matter
+// which shell (if any) invoked burrow. A backslash escapes '{', '}', ','
+// and itself.
+func Expand(path string) ([]string, error) {
+	expanded, err := expand(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range expanded {
+		expanded[i] = unescape(p)
+	}
+	return expanded, nil
+}
+
+// ExpandAll expands every path in order
+func ExpandAll(paths []string) ([]string, error) {
+	var out []string
+	for _, path := range paths {
+		expanded, err := Expand(path)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, expanded...)
+	}
+	return out, nil
+}
+
+func expand(s string) ([]string, error) {
+	for start := 0; start < len(s); start++ {
+		open := indexUnescaped(s, '{', start)
+		if open < 0 {
+			break
+		}
+		end := matchingBrace(s, open)
+		if end < 0 {
+			break
+		}
+
+		body := s[open+1 : end]
+		var alternatives []string
+		if parts := splitTopLevel(body); len(parts) > 1 {
+			for _, part := range parts {
+				sub, err := expand(part)
+				if err != nil {
+					return nil, err
+				}
+				alternatives = append(alternatives, sub...)
+			}
+		} else if seq, ok, err := sequence(body); err != nil {
+			return nil, err
+		} else if ok {
+			alternatives = seq
+		} else {
+			// Not an expansion, keep the braces literally and keep looking
+			start = open
+			continue
+		}
+
+		suffixes, err := expand(s[end+1:])
+		if err != nil {
+			return nil, err
+		}
+		if len(alternatives)*len(suffixes) > MaxExpansions {
+			return nil, fmt.Errorf("brace expansion of %q exceeds %d paths", s, MaxExpansions)
+		}
+
+		prefix := s[:open]
+		out := make([]string, 0, len(alternatives)*len(suffixes))
+		for _, alt := range alternatives {
+			for _, suffix := range suffixes {
+				out = append(out, prefix+alt+suffix)
+			}
+		}
+		return out, nil
+	}
+	return []string{s}, nil
+}
+
+// indexUnescaped returns the index of the first unescaped c at or after from
+func indexUnescaped(s string, c byte, from int) int {
+	for i := from; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case c:
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingBrace returns the index of the '}' closing the '{' at open
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s on commas that are neither escaped nor nested
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, last := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// sequence expands "1..10", "01..31", "a..e" and their "..step" variants
+func sequence(body string) ([]string, bool, error) {
+	if m := numericRange.FindStringSubmatch(body); m != nil {
+		from, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid range start %q", m[1])
+		}
+		to, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid range end %q", m[2])
+		}
+		step, err := rangeStep(m[3])
+		if err != nil {
+			return nil, false, err
+		}
+
+		width := 0
+		if zeroPadded(m[1]) || zeroPadded(m[2]) {
+			width = max(len(m[1]), len(m[2]))
+		}
+
+		if span(from, to)/step >= MaxExpansions {
+			return nil, false, fmt.Errorf("range %q exceeds %d paths", body, MaxExpansions)
+		}
+
+		var out []string
+		for _, n := range steps(from, to, step) {
+			out = append(out, pad(n, width))
+		}
+		return out, true, nil
+	}
+
+	if m := letterRange.FindStringSubmatch(body); m != nil {
+		step, err := rangeStep(m[3])
+		if err != nil {
+			return nil, false, err
+		}
+		var out []string
+		for _, n := range steps(int(m[1][0]), int(m[2][0]), step) {
+			out = append(out, string(rune(n)))
+		}
+		return out, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// rangeStep returns the size of a step, ignoring its sign as bash does
+func rangeStep(s string) (uint64, error) {
+	if s == "" {
+		return 1, nil
+	}
+	step, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid range step %q", s)
+	}
+	size := uint64(step)
+	if step < 0 {
+		size = -size
+	}
+	return max(size, 1), nil
+}
+
+// steps returns from, from±step, ... up to to. The arithmetic is done on
+// uint64, which cannot overflow for any pair of ints.
+func steps(from, to int, step uint64) []int {
+	count := span(from, to)/step + 1
+	out := make([]int, 0, count)
+	for i := uint64(0); i < count; i++ {
+		if from <= to {
+			out = append(out, int(uint64(from)+i*step))
+		} else {
+			out = append(out, int(uint64(from)-i*step))
+		}
+	}
+	return out
+}
+
+// span returns the distance between from and to, which may not fit in an
+// int
+func span(from, to int) uint64 {
+	if from > to {
+		return uint64(from) - uint64(to)
+	}
+	return uint64(to) - uint64(from)
+}
+
+func zeroPadded(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	return len(s) > 1 && s[0] == '0'
+}
+
+func pad(n, width int) string {
+	if width == 0 {
+		return strconv.Itoa(n)
+	}
+	if n < 0 {
+		return "-" + fmt.Sprintf("%0*d", width-1, uint64(-n))
+	}
+	return fmt.Sprintf("%0*d", width, n)
+}
+
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(`{},\`, s[i+1]) >= 0 {
+			i++
+		}
+		buf.WriteByte(s[i])
+	}
+	return buf.String()
+}
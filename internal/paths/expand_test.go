@@ -0,0 +1,100 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package paths
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"plain/path.go", []string{"plain/path.go"}},
+		{"src/{api,web}/main.go", []string{"src/api/main.go", "src/web/main.go"}},
+		{"{a,b}{1,2}", []string{"a1", "a2", "b1", "b2"}},
+		{"{a,{b,c}}", []string{"a", "b", "c"}},
+		{"day{01..03}.md", []string{"day01.md", "day02.md", "day03.md"}},
+		{"{3..1}", []string{"3", "2", "1"}},
+		{"{0..10..5}", []string{"0", "5", "10"}},
+		{"{0..10..-5}", []string{"0", "5", "10"}},
+		{"{-1..1}", []string{"-1", "0", "1"}},
+		{"{-03..01}", []string{"-03", "-02", "-01", "000", "001"}},
+		{"{a..c}", []string{"a", "b", "c"}},
+		{"{e..a..2}", []string{"e", "c", "a"}},
+		{"{single}", []string{"{single}"}},
+		{"open{", []string{"open{"}},
+		{`lit\{a,b\}`, []string{"lit{a,b}"}},
+		{`{a\,b,c}`, []string{"a,b", "c"}},
+		{"{9223372036854775806..9223372036854775807}", []string{"9223372036854775806", "9223372036854775807"}},
+		{"{-9223372036854775808..-9223372036854775807}", []string{"-9223372036854775808", "-9223372036854775807"}},
+	}
+	for _, tt := range tests {
+		got, err := Expand(tt.in)
+		if err != nil {
+			t.Errorf("Expand(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpandLimits(t *testing.T) {
+	tests := []string{
+		"{1..200000}",
+		"{-1..9223372036854775807}",
+		"{-9223372036854775808..9223372036854775807}",
+		"{9223372036854775807..-9223372036854775808..3}",
+		"{1..1000}{1..1000}",
+		"{1..99999999999999999999}",
+	}
+	for _, in := range tests {
+		if got, err := Expand(in); err == nil {
+			t.Errorf("Expand(%q) returned %d paths, want an error", in, len(got))
+		}
+	}
+}
+
+func TestExpandAll(t *testing.T) {
+	got, err := ExpandAll([]string{"a{1,2}", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a1", "a2", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	_, err = ExpandAll([]string{"ok", "big{1..200000}"})
+	if err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("got %v, want the expansion limit error", err)
+	}
+}
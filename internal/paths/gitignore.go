@@ -0,0 +1,130 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package paths
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type ignoreRule struct {
+	base     string // directory of the .gitignore, relative to the root
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// GitIgnore matches paths against the .gitignore files of a tree
+type GitIgnore struct {
+	root   string
+	rules  []ignoreRule
+	loaded map[string]bool
+}
+
+// NewGitIgnore returns a matcher for the tree rooted at root. Nested
+// .gitignore files are picked up with Load as the tree is walked.
+func NewGitIgnore(root string) *GitIgnore {
+	g := &GitIgnore{root: root, loaded: make(map[string]bool)}
+	g.Load("")
+	return g
+}
+
+// Load reads the .gitignore in dir, relative to the root, if there is one.
+// Each directory is only read once.
+func (g *GitIgnore) Load(dir string) {
+	if g.loaded[dir] {
+		return
+	}
+	g.loaded[dir] = true
+
+	// #nosec G304 - reading .gitignore files of the tree being walked
+	f, err := os.Open(filepath.Join(g.root, filepath.FromSlash(dir), ".gitignore"))
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " ")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: dir}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		rule.anchored = strings.Contains(line, "/")
+		rule.pattern = strings.TrimPrefix(line, "/")
+		g.rules = append(g.rules, rule)
+	}
+}
+
+// Ignored reports whether the slash separated path rel is ignored. The
+// last matching rule wins, as in git.
+func (g *GitIgnore) Ignored(rel string, isDir bool) bool {
+	if path.Base(rel) == ".git" {
+		return true
+	}
+
+	ignored := false
+	for _, rule := range g.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		sub := rel
+		if rule.base != "" {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			sub = strings.TrimPrefix(rel, rule.base+"/")
+		}
+
+		var matched bool
+		if rule.anchored {
+			matched = matchSegments(strings.Split(rule.pattern, "/"), strings.Split(sub, "/"))
+		} else {
+			matched, _ = path.Match(rule.pattern, path.Base(sub))
+		}
+		if matched {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
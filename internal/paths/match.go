@@ -0,0 +1,86 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package paths
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether the slash separated path name matches pattern.
+// Besides the path.Match syntax, a "**" segment matches any number of
+// directories, and a pattern without a slash is matched against the base
+// name so "*.log" matches at any depth.
+func Match(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	name = strings.Trim(name, "/")
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		ok, _ := path.Match(strings.TrimSuffix(pattern, "/"), path.Base(name))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.TrimSuffix(pattern, "/"), "/"), strings.Split(name, "/"))
+}
+
+// MatchAny reports whether name matches any of the patterns
+func MatchAny(patterns []string, name string) bool {
+	_, ok := MatchFirst(patterns, name)
+	return ok
+}
+
+// MatchFirst returns the first of the patterns that name matches
+func MatchFirst(patterns []string, name string) (string, bool) {
+	for _, pattern := range patterns {
+		if Match(pattern, name) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,174 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package scaffold
+
+import (
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pt "github.com/elaurentium/burrow/internal/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// Save registers the directory src as a template called name in source.
+// Files are copied verbatim, skipping anything ignored by .gitignore, so
+// their contents are not rendered unless renamed to end in .tmpl. The copy
+// is made next to the template and only replaces an existing one, with
+// force, once it is complete.
+func Save(name, src string, source Source, description string, force bool) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid template name: %q", name)
+	}
+
+	dir, err := SourceDir(source)
+	if err != nil {
+		return "", err
+	}
+	dst := filepath.Join(dir, name)
+	_, statErr := os.Stat(dst)
+	if statErr == nil && !force {
+		return "", fmt.Errorf("template %s already exists in %s, use --force to replace it", name, dst)
+	}
+	if within(src, dst) {
+		return "", fmt.Errorf("cannot save %s over itself: it is the template %s or inside it", src, name)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(dir, "."+name+"-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	if err := copyTemplate(src, tmp, dst, description); err != nil {
+		return "", err
+	}
+
+	if statErr == nil {
+		old := tmp + ".old"
+		if err := os.Rename(dst, old); err != nil {
+			return "", err
+		}
+		if err := os.Rename(tmp, dst); err != nil {
+			_ = os.Rename(old, dst)
+			return "", err
+		}
+		return dst, os.RemoveAll(old)
+	}
+	return dst, os.Rename(tmp, dst)
+}
+
+// within reports whether src is dir or lies inside it, once symlinks are
+// resolved
+func within(src, dir string) bool {
+	realSrc, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return false
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(realDir, realSrc)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// copyTemplate copies src into the new directory tmp. Saving a directory
+// into its own .burrow must not copy the copy, nor the template it replaces.
+func copyTemplate(src, tmp, dst, description string) error {
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	absTmp, err := filepath.Abs(tmp)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, 0755); err != nil {
+		return err
+	}
+
+	ignore := pt.NewGitIgnore(src)
+	err = filepath.WalkDir(src, func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if ignore.Ignored(filepath.ToSlash(rel), d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if abs, err := filepath.Abs(p); err == nil && (abs == absDst || abs == absTmp) {
+				return filepath.SkipDir
+			}
+		}
+
+		target := filepath.Join(tmp, rel)
+		switch {
+		case d.IsDir():
+			ignore.Load(filepath.ToSlash(rel))
+			return os.MkdirAll(target, 0755)
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			// #nosec G304 - p comes from walking the directory being saved
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, info.Mode().Perm())
+		default:
+			fmt.Fprintf(os.Stderr, "skipping %s: only directories and regular files are saved\n", p)
+			return nil
+		}
+	})
+	if err != nil || description == "" {
+		return err
+	}
+
+	data, err := yaml.Marshal(&Template{Description: description})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(tmp, MetadataFile), data, 0644)
+}
@@ -0,0 +1,79 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveForce(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	src := t.TempDir()
+	write := func(name, data string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(src, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.txt", "one")
+
+	dst, err := Save("web", src, SourceUser, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Save("web", src, SourceUser, "", false); err == nil {
+		t.Fatal("saving over an existing template without force: want an error")
+	}
+
+	// Saving the template, or a directory inside it, over itself must fail
+	// and leave it intact
+	for _, self := range []string{dst, filepath.Join(dst, ".")} {
+		if _, err := Save("web", self, SourceUser, "", true); err == nil {
+			t.Fatalf("saving %s over itself: want an error", self)
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(dst, "a.txt")); err != nil || string(data) != "one" {
+		t.Fatalf("template damaged by a refused save: %q, %v", data, err)
+	}
+
+	write("a.txt", "two")
+	if _, err := Save("web", src, SourceUser, "fresh", true); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dst, "a.txt")); err != nil || string(data) != "two" {
+		t.Fatalf("template not replaced: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, MetadataFile)); err != nil {
+		t.Fatalf("description not saved: %v", err)
+	}
+	leftovers, err := filepath.Glob(filepath.Join(filepath.Dir(dst), ".web-*"))
+	if err != nil || len(leftovers) > 0 {
+		t.Fatalf("temporary copies left behind: %v", leftovers)
+	}
+}
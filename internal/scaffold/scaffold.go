@@ -0,0 +1,212 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	create "github.com/elaurentium/burrow/internal/fs"
+	"github.com/elaurentium/burrow/internal/shell"
+	"github.com/elaurentium/burrow/templates"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MetadataFile describes a template and is never rendered
+	MetadataFile = "template.yaml"
+	// TemplateExt marks files whose content is rendered; it is stripped
+	// from the created file name. Other files are copied verbatim.
+	TemplateExt = ".tmpl"
+)
+
+// Variable is a value a template asks for when it is rendered
+type Variable struct {
+	Name    string `yaml:"name"`
+	Prompt  string `yaml:"prompt,omitempty"`
+	Default string `yaml:"default,omitempty"`
+}
+
+// Template is a project skeleton whose paths and contents are rendered
+// with text/template
+type Template struct {
+	Name        string     `yaml:"-"`
+	Description string     `yaml:"description,omitempty"`
+	Variables   []Variable `yaml:"variables,omitempty"`
+	Source      Source     `yaml:"-"`
+	Dir         string     `yaml:"-"` // on-disk location, empty for built-ins
+	Shadowed    bool       `yaml:"-"` // hidden by a template of higher precedence
+	fsys        iofs.FS
+}
+
+// Load reads the template rooted at fsys
+func Load(name string, source Source, fsys iofs.FS) (*Template, error) {
+	t := &Template{Name: name, Source: source, fsys: fsys}
+	data, err := iofs.ReadFile(fsys, MetadataFile)
+	if err != nil && !errors.Is(err, iofs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(data, t); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+	}
+	return t, nil
+}
+
+// Builtin returns the templates embedded in the binary
+func Builtin() ([]*Template, error) {
+	root, err := iofs.Sub(templates.Scaffold, "scaffold")
+	if err != nil {
+		return nil, err
+	}
+	return loadDir(root, SourceBuiltin)
+}
+
+// loadDir loads every template directory directly below root
+func loadDir(root iofs.FS, source Source) ([]*Template, error) {
+	dirs, err := iofs.ReadDir(root, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Template
+	for _, d := range dirs {
+		// Hidden directories are copies left by an interrupted save
+		if !d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			continue
+		}
+		sub, err := iofs.Sub(root, d.Name())
+		if err != nil {
+			return nil, err
+		}
+		t, err := Load(d.Name(), source, sub)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, t)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all, nil
+}
+
+// Files lists the template files, relative to the template root
+func (t *Template) Files() ([]string, error) {
+	var files []string
+	err := iofs.WalkDir(t.fsys, ".", func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && p != MetadataFile {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// ReadFile returns the raw content of a template file
+func (t *Template) ReadFile(name string) ([]byte, error) {
+	return iofs.ReadFile(t.fsys, name)
+}
+
+// Render renders the template into entries below dir
+func (t *Template) Render(dir string, vars map[string]string) ([]create.Entry, error) {
+	files, err := t.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []create.Entry{{Path: dir + "/", Type: create.TypeDir}}
+	for _, file := range files {
+		name, err := renderPath(file, vars)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		content, err := iofs.ReadFile(t.fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(name, TemplateExt) {
+			name = strings.TrimSuffix(name, TemplateExt)
+			rendered, err := render(file, string(content), vars)
+			if err != nil {
+				return nil, err
+			}
+			content = []byte(rendered)
+		}
+
+		entries = append(entries, create.Entry{
+			Path:    path.Join(dir, name),
+			Type:    create.TypeFile,
+			Content: content,
+		})
+	}
+	return entries, nil
+}
+
+// ResolveDefault renders the default value of a variable, which may refer
+// to variables that are already known
+func ResolveDefault(v Variable, vars map[string]string) (string, error) {
+	return render(v.Name, v.Default, vars)
+}
+
+func renderPath(p string, vars map[string]string) (string, error) {
+	if !strings.Contains(p, "{{") {
+		return p, nil
+	}
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		rendered, err := render(p, segment, vars)
+		if err != nil {
+			return "", err
+		}
+		if rendered == "" || strings.ContainsAny(rendered, `/\`) || rendered == ".." {
+			return "", fmt.Errorf("invalid file name %q", rendered)
+		}
+		segments[i] = rendered
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+func render(name, text string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(name).Funcs(shell.FuncMap()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,145 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+// Source is where a template was found. When several sources define a
+// template with the same name, the project one wins over the user one,
+// which wins over the built-in one.
+type Source string
+
+const (
+	SourceProject Source = "project"
+	SourceUser    Source = "user"
+	SourceBuiltin Source = "builtin"
+)
+
+// precedence lists the sources from highest to lowest priority
+var precedence = []Source{SourceProject, SourceUser, SourceBuiltin}
+
+// SourceDir returns the template directory of an on-disk source
+func SourceDir(source Source) (string, error) {
+	switch source {
+	case SourceUser:
+		dir, err := pt.ConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "templates"), nil
+	case SourceProject:
+		dir, ok := pt.ProjectDir()
+		if !ok {
+			wd, err := os.Getwd()
+			if err != nil {
+				return "", err
+			}
+			dir = filepath.Join(wd, pt.ProjectDirName)
+		}
+		return filepath.Join(dir, "templates"), nil
+	default:
+		return "", fmt.Errorf("%s templates are not stored on disk", source)
+	}
+}
+
+// All returns the templates of every source, ordered by name and then by
+// precedence. Templates hidden by one of higher precedence are marked as
+// shadowed.
+func All() ([]*Template, error) {
+	var all []*Template
+	for _, source := range precedence {
+		var (
+			found []*Template
+			err   error
+		)
+		if source == SourceBuiltin {
+			found, err = Builtin()
+		} else {
+			found, err = loadSource(source)
+		}
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, found...)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	seen := make(map[string]bool)
+	for _, t := range all {
+		t.Shadowed = seen[t.Name]
+		seen[t.Name] = true
+	}
+	return all, nil
+}
+
+// Find returns the template called name from the source of highest
+// precedence that has one
+func Find(name string) (*Template, error) {
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range all {
+		if t.Name == name && !t.Shadowed {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown template: %s", name)
+}
+
+func loadSource(source Source) ([]*Template, error) {
+	if source == SourceProject {
+		if _, ok := pt.ProjectDir(); !ok {
+			return nil, nil
+		}
+	}
+	dir, err := SourceDir(source)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); errors.Is(err, iofs.ErrNotExist) {
+		return nil, nil
+	}
+
+	found, err := loadDir(os.DirFS(dir), source)
+	if err != nil {
+		return nil, fmt.Errorf("%s templates: %w", source, err)
+	}
+	for _, t := range found {
+		t.Dir = filepath.Join(dir, t.Name)
+	}
+	return found, nil
+}
@@ -0,0 +1,128 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package shell
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pt "github.com/elaurentium/burrow/internal/paths"
+)
+
+// SessionEnv is set by the init scripts to the PID of the shell, so the
+// hook of that shell finds what burrow left for it
+const SessionEnv = "BURROW_SESSION"
+
+// ErrNoSession is returned when burrow does not run below a shell with the
+// integration installed
+var ErrNoSession = errors.New("shell integration is not installed, add eval \"$(b init <shell>)\" to your shell's rc file")
+
+// pwshQuotes doubles every character PowerShell accepts as a single quote,
+// the typographic ones included, so none of them ends a literal string
+var pwshQuotes = strings.NewReplacer("'", "''", "\u2018", "\u2018\u2018", "\u2019", "\u2019\u2019", "\u201a", "\u201a\u201a", "\u201b", "\u201b\u201b")
+
+// pendingPath returns the state file holding the directory the shell of
+// the current session should change to
+func pendingPath() (string, error) {
+	session := os.Getenv(SessionEnv)
+	if session == "" {
+		return "", ErrNoSession
+	}
+	if strings.ContainsAny(session, `/\.`) {
+		return "", fmt.Errorf("invalid %s: %q", SessionEnv, session)
+	}
+	dir, err := pt.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cd", session), nil
+}
+
+// SetPendingCd leaves dir for the prompt hook of the current shell, which
+// changes into it before the next prompt
+func SetPendingCd(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	path, err := pendingPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(abs), 0600)
+}
+
+// TakePendingCd returns and clears the directory left by SetPendingCd, or
+// "" when there is none
+func TakePendingCd() (string, error) {
+	path, err := pendingPath()
+	if errors.Is(err, ErrNoSession) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	// #nosec G304 - the state file lives in the burrow state directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	if err := os.Remove(path); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// CdCommand returns the code that changes the directory of shell to dir
+func CdCommand(shell, dir string) (string, error) {
+	switch shell {
+	case "bash", "zsh":
+		return "cd -- '" + strings.ReplaceAll(dir, "'", `'\''`) + "'", nil
+	case "fish":
+		return "cd '" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(dir) + "'", nil
+	case "pwsh":
+		return "Set-Location -LiteralPath '" + pwshQuotes.Replace(dir) + "'", nil
+	case "nu":
+		// The Nushell hook reads a record of actions, not code
+		quoted, err := json.Marshal(dir)
+		if err != nil {
+			return "", err
+		}
+		return "{cd: " + string(quoted) + "}", nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s", shell)
+	}
+}
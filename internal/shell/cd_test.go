@@ -0,0 +1,64 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package shell
+
+import "testing"
+
+func TestCdCommand(t *testing.T) {
+	dir := `/tmp/it's a \ $HOME dir`
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{"bash", `cd -- '/tmp/it'\''s a \ $HOME dir'`},
+		{"zsh", `cd -- '/tmp/it'\''s a \ $HOME dir'`},
+		{"fish", `cd '/tmp/it\'s a \\ $HOME dir'`},
+		{"pwsh", `Set-Location -LiteralPath '/tmp/it''s a \ $HOME dir'`},
+		{"nu", `{cd: "/tmp/it's a \\ $HOME dir"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			got, err := CdCommand(tt.shell, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	quotes := "/tmp/\u2018a\u2019 \u201ab\u201b"
+	want := "Set-Location -LiteralPath '/tmp/\u2018\u2018a\u2019\u2019 \u201a\u201ab\u201b\u201b'"
+	if got, err := CdCommand("pwsh", quotes); err != nil || got != want {
+		t.Errorf("pwsh typographic quotes: got %s, %v, want %s", got, err, want)
+	}
+
+	if _, err := CdCommand("tcsh", dir); err == nil {
+		t.Error("tcsh: expected an unsupported shell error")
+	}
+}
@@ -1,16 +1,38 @@
 package shell
 
+import (
+	"fmt"
+	"strings"
+)
+
+// InitHook is the shell hook an init script installs
 type InitHook int
 
 const (
-	None InitHook = iota
+	None   InitHook = iota // no hook, only BURROW_CMD is set
+	Prompt                 // run "b hook prompt" before every prompt
 )
 
 func (h InitHook) String() string {
 	switch h {
 	case None:
 		return "None"
+	case Prompt:
+		return "Prompt"
 	default:
 		return "Unknown"
 	}
-}
\ No newline at end of file
+}
+
+// Set parses a hook name, so InitHook can be used as a flag value
+func (h *InitHook) Set(value string) error {
+	for _, hook := range []InitHook{None, Prompt} {
+		if strings.EqualFold(value, hook.String()) {
+			*h = hook
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown hook %q, valid hooks: none, prompt", value)
+}
+
+func (h *InitHook) Type() string { return "hook" }
@@ -0,0 +1,186 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package shell
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Markers delimit the block burrow manages in a shell rc file
+const (
+	BeginMarker = "# >>> burrow initialize >>>"
+	EndMarker   = "# <<< burrow initialize <<<"
+)
+
+// BackupSuffix is appended to an rc file to name its backup
+const BackupSuffix = ".burrow.bak"
+
+// Detect returns the shell named by $SHELL, such as "zsh" for /bin/zsh
+func Detect() (string, error) {
+	path := os.Getenv("SHELL")
+	if path == "" {
+		return "", errors.New("cannot detect the shell: $SHELL is not set, name the shell instead")
+	}
+	name := strings.TrimSuffix(filepath.Base(path), ".exe")
+	if name == "nushell" {
+		name = "nu"
+	}
+	return name, nil
+}
+
+// RcFile returns the startup file the integration of shell is installed in
+func RcFile(shell string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	config := os.Getenv("XDG_CONFIG_HOME")
+	if config == "" {
+		config = filepath.Join(home, ".config")
+	}
+
+	switch shell {
+	case "bash":
+		return filepath.Join(home, ".bashrc"), nil
+	case "zsh":
+		if dir := os.Getenv("ZDOTDIR"); dir != "" {
+			return filepath.Join(dir, ".zshrc"), nil
+		}
+		return filepath.Join(home, ".zshrc"), nil
+	case "fish":
+		return filepath.Join(config, "fish", "config.fish"), nil
+	case "pwsh":
+		return filepath.Join(config, "powershell", "Microsoft.PowerShell_profile.ps1"), nil
+	default:
+		return "", fmt.Errorf("installing is not supported for %s, add the output of \"b init %s\" to its config by hand", shell, shell)
+	}
+}
+
+// Snippet returns the line that loads the integration, calling burrow as
+// cmd with the extra init arguments
+func Snippet(shell, cmd string, args ...string) string {
+	invocation := strings.Join(append([]string{cmd, "init", shell}, args...), " ")
+	switch shell {
+	case "fish":
+		return invocation + " | source"
+	case "pwsh":
+		return "Invoke-Expression (& " + invocation + " | Out-String)"
+	default:
+		return `eval "$(` + invocation + `)"`
+	}
+}
+
+// Install writes the snippet into the rc file between the markers,
+// replacing a block installed before. It reports whether the file changed,
+// and backs it up first.
+func Install(path, snippet string) (bool, error) {
+	content, err := readRc(path)
+	if err != nil {
+		return false, err
+	}
+	block := BeginMarker + "\n" + snippet + "\n" + EndMarker + "\n"
+
+	updated, found := replaceBlock(content, block)
+	if !found {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if content != "" {
+			content += "\n"
+		}
+		updated = content + block
+	}
+	return writeRc(path, content, updated)
+}
+
+// Uninstall removes the block from the rc file, backing it up first, and
+// reports whether there was one
+func Uninstall(path string) (bool, error) {
+	content, err := readRc(path)
+	if err != nil {
+		return false, err
+	}
+	updated, found := replaceBlock(content, "")
+	if !found {
+		return false, nil
+	}
+	// Drop the blank line Install put before the block
+	if i := strings.Index(content, BeginMarker); i > 1 && content[i-2:i] == "\n\n" {
+		updated = content[:i-1] + updated[i:]
+	}
+	return writeRc(path, content, updated)
+}
+
+// replaceBlock replaces the marked block, including its trailing newline,
+// with block
+func replaceBlock(content, block string) (string, bool) {
+	begin := strings.Index(content, BeginMarker)
+	if begin < 0 {
+		return content, false
+	}
+	end := strings.Index(content[begin:], EndMarker)
+	if end < 0 {
+		return content, false
+	}
+	end += begin + len(EndMarker)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:begin] + block + content[end:], true
+}
+
+func readRc(path string) (string, error) {
+	// #nosec G304 - path is the rc file of the user's shell
+	data, err := os.ReadFile(path)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return "", nil
+	}
+	return string(data), err
+}
+
+// writeRc writes the updated rc file in place, so symlinked dotfiles stay
+// symlinks, after saving the previous content next to it
+func writeRc(path, previous, updated string) (bool, error) {
+	if updated == previous {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if err := os.WriteFile(path+BackupSuffix, []byte(previous), 0600); err != nil {
+			return false, fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	// #nosec G306 - rc files are readable like the ones shells create
+	return true, os.WriteFile(path, []byte(updated), 0644)
+}
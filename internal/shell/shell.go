@@ -27,22 +27,26 @@
 package shell
 
 import (
-	_ "embed"
 	"strings"
 	"text/template"
+
+	"github.com/elaurentium/burrow/pkg/utils"
+	"github.com/elaurentium/burrow/templates"
 )
 
 var (
-	//go:embed../../templates/bash.txt
-	bashTemplate string
-	//go:embed../../templates/zsh.txt
-	zshTemplate string
+	bashTemplate    = templates.Bash
+	zshTemplate     = templates.Zsh
+	fishTemplate    = templates.Fish
+	pwshTemplate    = templates.Pwsh
+	nushellTemplate = templates.Nushell
 )
 
+// Opts configure the rendered init script
 type Opts struct {
-	Cmd  string
-	Hook InitHook
-	Echo bool
+	Cmd  string   // name the shell calls burrow by, "b" when empty
+	Hook InitHook // hook the script installs
+	Echo bool     // print what the hook evaluates before running it
 }
 
 type ShellTemplate struct {
@@ -58,6 +62,18 @@ type Zsh struct {
 	ShellTemplate
 }
 
+type Fish struct {
+	ShellTemplate
+}
+
+type Pwsh struct {
+	ShellTemplate
+}
+
+type Nushell struct {
+	ShellTemplate
+}
+
 func (st *ShellTemplate) Execute() (string, error) {
 	var buf strings.Builder
 	if err := st.tmpl.Execute(&buf, st.Opts); err != nil {
@@ -66,8 +82,11 @@ func (st *ShellTemplate) Execute() (string, error) {
 	return buf.String(), nil
 }
 
-func (b *Bash) Render() (string, error) { return b.Execute() }
-func (z *Zsh) Render() (string, error)  { return z.Execute() }
+func (b *Bash) Render() (string, error)    { return b.Execute() }
+func (z *Zsh) Render() (string, error)     { return z.Execute() }
+func (f *Fish) Render() (string, error)    { return f.Execute() }
+func (p *Pwsh) Render() (string, error)    { return p.Execute() }
+func (n *Nushell) Render() (string, error) { return n.Execute() }
 
 func Default(val, fallback string) string {
 	if strings.TrimSpace(val) == "" {
@@ -80,15 +99,23 @@ func Replace(s, old, new string) string {
 	return strings.ReplaceAll(s, old, new)
 }
 
-func funcMap() template.FuncMap {
+// FuncMap returns the helpers available to every burrow template
+func FuncMap() template.FuncMap {
 	return template.FuncMap{
 		"Default": Default,
 		"Replace": Replace,
+		"Lower":   strings.ToLower,
+		"Upper":   strings.ToUpper,
+		"Title":   utils.ToTitle,
+		"Camel":   utils.ToCamel,
+		"Pascal":  utils.ToPascal,
+		"Snake":   utils.ToSnake,
+		"Kebab":   utils.ToKebab,
 	}
 }
 
 func NewBash(opts *Opts) (*Bash, error) {
-	tmpl, err := template.New("bash").Funcs(funcMap()).Parse(bashTemplate)
+	tmpl, err := template.New("bash").Funcs(FuncMap()).Parse(bashTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -96,9 +123,33 @@ func NewBash(opts *Opts) (*Bash, error) {
 }
 
 func NewZsh(opts *Opts) (*Zsh, error) {
-	tmpl, err := template.New("zsh").Funcs(funcMap()).Parse(zshTemplate)
+	tmpl, err := template.New("zsh").Funcs(FuncMap()).Parse(zshTemplate)
 	if err != nil {
 		return nil, err
 	}
 	return &Zsh{ShellTemplate{Opts: opts, tmpl: tmpl}}, nil
 }
+
+func NewFish(opts *Opts) (*Fish, error) {
+	tmpl, err := template.New("fish").Funcs(FuncMap()).Parse(fishTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &Fish{ShellTemplate{Opts: opts, tmpl: tmpl}}, nil
+}
+
+func NewPwsh(opts *Opts) (*Pwsh, error) {
+	tmpl, err := template.New("pwsh").Funcs(FuncMap()).Parse(pwshTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &Pwsh{ShellTemplate{Opts: opts, tmpl: tmpl}}, nil
+}
+
+func NewNushell(opts *Opts) (*Nushell, error) {
+	tmpl, err := template.New("nushell").Funcs(FuncMap()).Parse(nushellTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &Nushell{ShellTemplate{Opts: opts, tmpl: tmpl}}, nil
+}
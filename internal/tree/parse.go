@@ -0,0 +1,174 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package tree
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const tabWidth = 4
+
+var (
+	// "3 directories, 5 files" footer printed by the tree command
+	treeSummary = regexp.MustCompile(`^\d+ director(y|ies)(, \d+ files?)?$`)
+	// Markdown ordered list markers such as "1." or "2)"
+	orderedMarker = regexp.MustCompile(`^\d+[.)]\s+`)
+)
+
+type node struct {
+	column int
+	path   string
+}
+
+// Parse reads an indented tree description and returns the paths it
+// describes in order. It understands plain indented outlines, the output of
+// the tree command (both the unicode and the ASCII glyphs) and Markdown
+// nested lists. Entries that have children, or that end in "/", are
+// returned with a trailing "/" so they are created as directories.
+func Parse(r io.Reader) ([]string, error) {
+	var (
+		stack []node
+		paths []string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		column, name := splitLine(scanner.Text())
+		if name == "" {
+			continue
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].column >= column {
+			stack = stack[:len(stack)-1]
+		}
+
+		p := name
+		if len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			markDir(paths, parent.path)
+			p = path.Join(parent.path, name)
+			if strings.HasSuffix(name, "/") {
+				p += "/"
+			}
+		}
+
+		stack = append(stack, node{column: column, path: p})
+		if p != "." && p != "./" {
+			paths = append(paths, p)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// markDir adds a trailing slash to the entry for dir once it has children
+func markDir(paths []string, dir string) {
+	for i := len(paths) - 1; i >= 0; i-- {
+		if strings.TrimSuffix(paths[i], "/") == strings.TrimSuffix(dir, "/") {
+			if !strings.HasSuffix(paths[i], "/") {
+				paths[i] += "/"
+			}
+			return
+		}
+	}
+}
+
+// splitLine returns the display column where the entry name starts and the
+// cleaned name, or an empty name for lines that describe no entry.
+func splitLine(line string) (int, string) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") ||
+		treeSummary.MatchString(trimmed) {
+		return 0, ""
+	}
+
+	column := 0
+	rest := line
+scan:
+	for rest != "" {
+		r, size := utf8.DecodeRuneInString(rest)
+		switch r {
+		case ' ', '\u00a0':
+			column++
+		case '\t':
+			column += tabWidth
+		default:
+			if !isGlyphPrefix(rest) {
+				break scan
+			}
+			column++
+		}
+		rest = rest[size:]
+	}
+
+	rest = orderedMarker.ReplaceAllString(rest, "")
+	return column, cleanName(rest)
+}
+
+// isGlyphPrefix reports whether s starts with tree drawing characters or a
+// list marker, rather than a name that merely begins with one of them.
+func isGlyphPrefix(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	switch r {
+	case '│', '├', '└', '─':
+		return true
+	case '|', '`':
+		// ASCII tree: "|-- name", "`-- name" and the "|   " continuation
+		next := s[size:]
+		return strings.HasPrefix(next, "--") || strings.HasPrefix(next, " ") || next == ""
+	case '-':
+		// ASCII tree "--" continuation or Markdown "- " bullet
+		next := s[size:]
+		return strings.HasPrefix(next, "-") || strings.HasPrefix(next, " ")
+	case '*', '+':
+		return strings.HasPrefix(s[size:], " ")
+	}
+	return false
+}
+
+func cleanName(s string) string {
+	s = strings.TrimSpace(s)
+	// Trailing comments: "main.go  # entrypoint"
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	// Symlinks as printed by tree: "current -> v2"
+	if i := strings.Index(s, " -> "); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	s = strings.Trim(s, "`*")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,127 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package tree
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/elaurentium/burrow/pkg/formatter"
+)
+
+// Item is a path shown in a rendered tree
+type Item struct {
+	Path  string
+	Dir   bool
+	Note  string // shown after the name, such as "(exists)"
+	Color string // ANSI colour of the label, used when colour is enabled
+}
+
+type renderNode struct {
+	name     string
+	item     *Item
+	children map[string]*renderNode
+}
+
+// Render writes items as an indented tree, in the style of the tree
+// command, with the current directory as the root. Labels are coloured
+// only when color is true.
+func Render(w io.Writer, items []Item, color bool) error {
+	root := &renderNode{name: ".", children: map[string]*renderNode{}}
+	for i := range items {
+		item := &items[i]
+		node := root
+		for _, segment := range segments(item.Path) {
+			child, ok := node.children[segment]
+			if !ok {
+				child = &renderNode{name: segment, children: map[string]*renderNode{}}
+				node.children[segment] = child
+			}
+			node = child
+		}
+		node.item = item
+	}
+
+	if _, err := fmt.Fprintln(w, root.name); err != nil {
+		return err
+	}
+	return root.renderChildren(w, "", color)
+}
+
+func (n *renderNode) renderChildren(w io.Writer, prefix string, color bool) error {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		child := n.children[name]
+		branch, indent := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		label := child.name
+		isDir := len(child.children) > 0 || (child.item != nil && child.item.Dir)
+		if isDir && !strings.HasSuffix(label, "/") {
+			label += "/"
+		}
+		if child.item != nil {
+			if child.item.Note != "" {
+				label += " " + child.item.Note
+			}
+			label = formatter.Colorize(label, child.item.Color, color)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s%s%s\n", prefix, branch, label); err != nil {
+			return err
+		}
+		if err := child.renderChildren(w, prefix+indent, color); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// segments splits a path into the names shown at each tree level
+func segments(p string) []string {
+	p = filepath.ToSlash(filepath.Clean(p))
+	var out []string
+	if strings.HasPrefix(p, "/") {
+		out = append(out, "/")
+	}
+	for _, s := range strings.Split(strings.Trim(p, "/"), "/") {
+		if s != "" && s != "." {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -24,24 +24,21 @@
 
 */
 
-package paths
-
-import (
-	"path/filepath"
-	"strings"
-
-	"github.com/elaurentium/burrow/internal/helper"
+package formatter
+
+// ANSI colour escape sequences, only written to terminals
+const (
+	Reset  = "\033[0m"
+	Red    = "\033[31m"
+	Green  = "\033[32m"
+	Yellow = "\033[33m"
+	Faint  = "\033[2m"
 )
 
-func IsFile(path string) bool {
-	if filepath.Ext(path) != "" {
-		return true
-	}
-	base := filepath.Base(path)
-	for _, file := range helper.FilesWithoutExtension {
-		if strings.EqualFold(base, file) {
-			return true
-		}
+// Colorize wraps s in the colour when enabled
+func Colorize(s, color string, enabled bool) string {
+	if !enabled || color == "" {
+		return s
 	}
-	return false
+	return color + s + Reset
 }
@@ -29,12 +29,95 @@ package utils
 import (
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 func StringToBool(str string) bool {
 	str = strings.ToLower(strings.TrimSpace(str))
-	if str == "y" { return true }
+	if str == "y" {
+		return true
+	}
 
 	b, _ := strconv.ParseBool(str)
 	return b
-}
\ No newline at end of file
+}
+
+// Words splits an identifier such as "myAPIServer", "my-api_server" or
+// "My API server" into lower case words.
+func Words(str string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(str)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(word) > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// "myAPI" -> my API, "APIServer" -> API Server
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// ToSnake converts str to snake_case
+func ToSnake(str string) string {
+	return strings.Join(Words(str), "_")
+}
+
+// ToKebab converts str to kebab-case
+func ToKebab(str string) string {
+	return strings.Join(Words(str), "-")
+}
+
+// ToPascal converts str to PascalCase
+func ToPascal(str string) string {
+	var buf strings.Builder
+	for _, w := range Words(str) {
+		buf.WriteString(capitalize(w))
+	}
+	return buf.String()
+}
+
+// ToCamel converts str to camelCase
+func ToCamel(str string) string {
+	words := Words(str)
+	for i := 1; i < len(words); i++ {
+		words[i] = capitalize(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// ToTitle converts str to space separated Title Case
+func ToTitle(str string) string {
+	words := Words(str)
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, " ")
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
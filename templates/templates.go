@@ -0,0 +1,60 @@
+/*
+
+	MIT License
+
+	Copyright (c) 2025 Evandro
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+
+*/
+
+package templates
+
+import "embed"
+
+// Shell init scripts printed by "b init", rendered with shell.Opts
+var (
+	//go:embed bash.txt
+	Bash string
+	//go:embed zsh.txt
+	Zsh string
+	//go:embed fish.txt
+	Fish string
+	//go:embed pwsh.txt
+	Pwsh string
+	//go:embed nushell.txt
+	Nushell string
+)
+
+// Scaffold holds the built-in project templates used by "b new", one
+// directory per template.
+//
+//go:embed all:scaffold
+var Scaffold embed.FS
+
+// Licenses holds the license texts used by "b license", one file per SPDX
+// identifier.
+//
+//go:embed licenses
+var Licenses embed.FS
+
+// Docs holds the CONTRIBUTING and CODE_OF_CONDUCT skeletons used by "b doc".
+//
+//go:embed docs
+var Docs embed.FS